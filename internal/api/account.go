@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/backup"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+)
+
+func (h *handler) exportAccount(w http.ResponseWriter, r *http.Request) {
+	var b bytes.Buffer
+	err := backup.NewHandler(h.store).Export(r.Context(), request.UserID(r),
+		&b)
+	if err != nil {
+		response.ServerErrorJSON(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", "application/zip").
+		WithHeader("Content-Disposition",
+			`attachment; filename="miniflux-account.zip"`).
+		WithBodyAsBytes(b.Bytes()).
+		Write()
+}
+
+func (h *handler) importAccount(w http.ResponseWriter, r *http.Request,
+) (*model.AccountRestoreResult, error) {
+	body := http.MaxBytesReader(w, r.Body, backup.MaxArchiveSize)
+	result, err := backup.NewHandler(h.store).Import(r.Context(),
+		request.UserID(r), body)
+	if errors.Is(err, backup.ErrInvalidArchive) {
+		return nil, response.WrapBadRequest(err)
+	} else if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -59,6 +59,8 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 			response.NoContentJSON(handler.markCategoryAsRead)).
 		HandleFunc("/categories/{categoryID}/feeds",
 			response.JSON(handler.getCategoryFeeds)).
+		HandleFunc("POST /categories/{categoryID}/apply-filters",
+			response.JSON(handler.applyCategoryFilters)).
 		HandleFunc("/categories/{categoryID}/refresh",
 			response.NoContentJSON(handler.refreshCategory)).
 		HandleFunc("/categories/{categoryID}/entries",
@@ -68,6 +70,9 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 		HandleFunc("/discover", response.JSON(handler.discoverSubscriptions)).
 		HandleFunc("POST /feeds", response.CreatedJSON(handler.createFeed)).
 		HandleFunc("GET /feeds", response.JSON(handler.getFeeds)).
+		HandleFunc("PUT /feeds", response.JSON(handler.updateFeeds)).
+		HandleFunc("POST /feeds/remove",
+			response.NoContentJSON(handler.removeFeeds)).
 		HandleFunc("GET /feeds/counters", response.JSON(handler.fetchCounters)).
 		HandleFunc("PUT /feeds/refresh",
 			response.NoContentJSON(handler.refreshAllFeeds)).
@@ -82,28 +87,77 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 			response.JSON(handler.getIconByFeedID)).
 		HandleFunc("/feeds/{feedID}/mark-all-as-read",
 			response.NoContentJSON(handler.markFeedAsRead)).
+		HandleFunc("POST /feeds/{feedID}/filter-preview",
+			response.JSON(handler.previewFeedFilter)).
+		HandleFunc("POST /feeds/{feedID}/apply-filters",
+			response.JSON(handler.applyFeedFilters)).
+		HandleFunc("POST /feeds/apply-filters",
+			response.JSON(handler.applyAllFilters)).
+		HandleFunc("GET /feeds/{feedID}/filter-hits",
+			response.JSON(handler.getFeedFilterRuleHits)).
+		HandleFunc("GET /filter-hits", response.JSON(handler.getFilterRuleHits)).
 		HandleFunc("/export", handler.exportFeeds).
 		HandleFunc("/import", response.CreatedJSON(handler.importFeeds)).
+		HandleFunc("GET /export/account", handler.exportAccount).
+		HandleFunc("POST /import/account",
+			response.CreatedJSON(handler.importAccount)).
 		HandleFunc("POST /import/entries",
 			response.CreatedJSON(handler.importEntries)).
 		HandleFunc("/feeds/{feedID}/entries",
 			response.JSON(handler.getFeedEntries)).
 		HandleFunc("/feeds/{feedID}/entries/{entryID}",
 			response.JSON(handler.getFeedEntry)).
+		HandleFunc("GET /events", handler.streamEvents).
 		HandleFunc("GET /entries", response.JSON(handler.getEntries)).
 		HandleFunc("PUT /entries", response.NoContentJSON(handler.setEntryStatus)).
 		HandleFunc("GET /entries/ids", response.JSON(handler.getEntryIDs)).
 		HandleFunc("GET /entries/{entryID}", response.JSON(handler.getEntry)).
 		HandleFunc("PUT /entries/{entryID}",
 			response.CreatedJSON(handler.updateEntry)).
+		HandleFunc("GET /entries/{entryID}/labels",
+			response.JSON(handler.getEntryLabels)).
+		HandleFunc("PUT /entries/{entryID}/labels",
+			response.NoContentJSON(handler.setEntryLabels)).
 		HandleFunc("/entries/{entryID}/bookmark",
 			response.NoContentJSON(handler.toggleBookmark)).
 		HandleFunc("/entries/{entryID}/save",
 			response.AcceptedJSON(handler.saveEntry)).
 		HandleFunc("/entries/{entryID}/fetch-content",
 			response.JSON(handler.fetchContent)).
+		HandleFunc("GET /entries/{entryID}/scraper-diagnostics",
+			response.JSON(handler.entryScraperDiagnostics)).
+		HandleFunc("GET /scraper-diagnostics",
+			response.JSON(handler.scraperDiagnostics)).
 		HandleFunc("PUT /entries/{entryID}/enclosure/{at}",
 			response.NoContentJSON(handler.updateEnclosureAt)).
+		HandleFunc("POST /labels", response.CreatedJSON(handler.createLabel)).
+		HandleFunc("GET /labels", response.JSON(handler.getLabels)).
+		HandleFunc("PUT /labels/{labelID}",
+			response.CreatedJSON(handler.updateLabel)).
+		HandleFunc("DELETE /labels/{labelID}",
+			response.NoContentJSON(handler.removeLabel)).
+		HandleFunc("POST /saved-searches",
+			response.CreatedJSON(handler.createSavedSearch)).
+		HandleFunc("GET /saved-searches",
+			response.JSON(handler.getSavedSearches)).
+		HandleFunc("PUT /saved-searches/{savedSearchID}",
+			response.CreatedJSON(handler.updateSavedSearch)).
+		HandleFunc("DELETE /saved-searches/{savedSearchID}",
+			response.NoContentJSON(handler.removeSavedSearch)).
+		HandleFunc("GET /saved-searches/{savedSearchID}/entries",
+			response.JSON(handler.getSavedSearchEntries)).
+		HandleFunc("PUT /saved-searches/{savedSearchID}/mark-all-as-read",
+			response.NoContentJSON(handler.markSavedSearchAsRead)).
+		HandleFunc("POST /site-rules",
+			response.CreatedJSON(handler.createSiteRule)).
+		HandleFunc("GET /site-rules", response.JSON(handler.getSiteRules)).
+		HandleFunc("PUT /site-rules/{siteRuleID}",
+			response.CreatedJSON(handler.updateSiteRule)).
+		HandleFunc("DELETE /site-rules/{siteRuleID}",
+			response.NoContentJSON(handler.removeSiteRule)).
+		HandleFunc("GET /site-rules/export", handler.exportSiteRules).
+		HandleFunc("POST /site-rules/import",
+			response.CreatedJSON(handler.importSiteRules)).
 		HandleFunc("/flush-history", response.AcceptedJSON(handler.flushHistory)).
 		HandleFunc("/icons/{iconID}", response.JSON(handler.getIconByIconID)).
 		HandleFunc("/integrations/status",
@@ -6,17 +6,22 @@ package api // import "miniflux.app/v2/internal/api"
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"io"
 	"net"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"reflect"
 	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	dotenv "github.com/dsh2dsh/expx-dotenv"
@@ -25,6 +30,7 @@ import (
 
 	"miniflux.app/v2/internal/client"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
 )
 
 type integrationConfig struct {
@@ -38,6 +44,7 @@ type integrationConfig struct {
 	SubscriptionTitle string `env:"TEST_MINIFLUX_SUBSCRIPTION_TITLE"`
 	WebsiteURL        string `env:"TEST_MINIFLUX_WEBSITE_URL"`
 	TestListenAddr    string `env:"TEST_LISTEN_ADDR"`
+	DatabaseURL       string `env:"DATABASE_URL"`
 
 	seq atomic.Int64
 }
@@ -685,6 +692,55 @@ func (self *EndpointTestSuite) TestCreateFeedEndpoint_2entries() {
 	self.Len(result.Entries, 2)
 }
 
+func (self *EndpointTestSuite) TestSearchEntriesWithLanguage() {
+	feedURL := self.makeFeedURL("/2entries.xml")
+	feedID := self.createFeedWith(
+		model.FeedCreationRequest{FeedURL: feedURL.String()})
+
+	// The feed is in English, so "logging" is indexed as "log".
+	result, err := self.client.FeedEntries(feedID,
+		&client.Filter{Search: "log"})
+	self.Require().NoError(err)
+	self.Require().Equal(2, result.Total)
+	for _, entry := range result.Entries {
+		self.Contains(entry.Snippet, "<mark>", "Snippets should highlight matches")
+	}
+
+	result, err = self.client.FeedEntries(feedID,
+		&client.Filter{Search: "calls"})
+	self.Require().NoError(err)
+	self.Equal(2, result.Total)
+}
+
+func (self *EndpointTestSuite) TestSearchEntriesWithFields() {
+	feedURL := self.makeFeedURL("/2entries.xml")
+	feedID := self.createFeedWith(
+		model.FeedCreationRequest{FeedURL: feedURL.String()})
+
+	result, err := self.client.FeedEntries(feedID,
+		&client.Filter{Search: `log title:"v2.2.8:" author:fguillot`})
+	self.Require().NoError(err)
+	self.Require().Equal(1, result.Total)
+	self.Equal("v2.2.8: Miniflux 2.2.8", result.Entries[0].Title)
+
+	result, err = self.client.FeedEntries(feedID, &client.Filter{
+		Search: "domain:github.com date:2025-04-23 is:unread",
+	})
+	self.Require().NoError(err)
+	self.Require().Equal(2, result.Total)
+	self.Empty(result.Entries[0].Snippet,
+		"Snippets are only returned for full-text searches")
+
+	result, err = self.client.FeedEntries(feedID,
+		&client.Filter{Search: "is:starred"})
+	self.Require().NoError(err)
+	self.Zero(result.Total)
+
+	_, err = self.client.FeedEntries(feedID,
+		&client.Filter{Search: "before:yesterday"})
+	self.Require().Error(err, "Invalid dates should be rejected")
+}
+
 func (self *EndpointTestSuite) TestCreateFeedEndpoint_2hash() {
 	feedURL := self.makeFeedURL("/2hash.xml")
 	self.T().Log(feedURL)
@@ -856,6 +912,185 @@ func (self *EndpointTestSuite) TestUpdateFeedEndpoint_CommentsURLTemplate() {
 	}
 }
 
+func (self *EndpointTestSuite) TestUpdateFeedEndpoint_RefreshInterval() {
+	feedID := self.createFeed()
+
+	feed, err := self.client.UpdateFeed(feedID,
+		&model.FeedModificationRequest{RefreshInterval: new(5)})
+	self.Require().NoError(err)
+	self.Require().NotNil(feed)
+	self.Equal(5, feed.RefreshInterval())
+
+	feed, err = self.client.UpdateFeed(feedID,
+		&model.FeedModificationRequest{RefreshInterval: new(0)})
+	self.Require().NoError(err)
+	self.Require().NotNil(feed)
+	self.Zero(feed.RefreshInterval())
+
+	_, err = self.client.UpdateFeed(feedID,
+		&model.FeedModificationRequest{RefreshInterval: new(-1)})
+	self.T().Log(err)
+	self.Require().Error(err)
+}
+
+func (self *EndpointTestSuite) TestUpdateFeedEndpoint_RenderPages() {
+	feedID := self.createFeed()
+
+	feed, err := self.client.UpdateFeed(feedID,
+		&model.FeedModificationRequest{RenderPages: new(true)})
+	self.Require().NoError(err)
+	self.Require().NotNil(feed)
+	self.True(feed.RenderPages())
+
+	feed, err = self.client.UpdateFeed(feedID,
+		&model.FeedModificationRequest{RenderPages: new(false)})
+	self.Require().NoError(err)
+	self.Require().NotNil(feed)
+	self.False(feed.RenderPages())
+}
+
+func (self *EndpointTestSuite) TestUpdateFeedEndpoint_Retention() {
+	feedID := self.createFeed()
+
+	feed, err := self.client.UpdateFeed(feedID, &model.FeedModificationRequest{
+		Retention: &model.Retention{Days: 30, Keep: 100},
+	})
+	self.Require().NoError(err)
+	self.Require().NotNil(feed)
+	self.Equal(model.Retention{Days: 30, Keep: 100}, feed.Extra.Retention)
+
+	feed, err = self.client.UpdateFeed(feedID, &model.FeedModificationRequest{
+		Retention: &model.Retention{},
+	})
+	self.Require().NoError(err)
+	self.Require().NotNil(feed)
+	self.Zero(feed.Extra.Retention)
+
+	_, err = self.client.UpdateFeed(feedID, &model.FeedModificationRequest{
+		Retention: &model.Retention{Never: true, Days: 1},
+	})
+	self.T().Log(err)
+	self.Require().Error(err)
+}
+
+func (self *EndpointTestSuite) TestArchiveEntriesByRetention() {
+	store := self.newStorage()
+	category := self.createCategory()
+	_, err := self.client.UpdateCategoryWithOptions(category.ID,
+		&model.CategoryModificationRequest{
+			Retention: &model.Retention{Keep: 1},
+		})
+	self.Require().NoError(err)
+
+	keepFeedID := self.createFeedWith(model.FeedCreationRequest{
+		FeedURL:    self.makeFeedURL("/2entries.xml?keep").String(),
+		CategoryID: category.ID,
+	})
+	neverFeedID := self.createFeedWith(model.FeedCreationRequest{
+		FeedURL:    self.makeFeedURL("/2entries.xml?never").String(),
+		CategoryID: category.ID,
+	})
+	_, err = self.client.UpdateFeed(neverFeedID, &model.FeedModificationRequest{
+		Retention: &model.Retention{Never: true},
+	})
+	self.Require().NoError(err)
+
+	_, err = store.ArchiveEntriesByRetention(self.T().Context(), 1000)
+	self.Require().NoError(err)
+
+	result, err := self.client.FeedEntries(keepFeedID, nil)
+	self.Require().NoError(err)
+	self.Require().Len(result.Entries, 1,
+		"Only the latest entry should be kept by the category policy")
+	self.Equal("Miniflux 2.2.8", result.Entries[0].Title)
+
+	result, err = self.client.FeedEntries(neverFeedID, nil)
+	self.Require().NoError(err)
+	self.Len(result.Entries, 2,
+		"The feed policy should win over the category policy")
+}
+
+func (self *EndpointTestSuite) newStorage() *storage.Storage {
+	self.T().Helper()
+	if self.cfg.DatabaseURL == "" {
+		self.T().Skip("DATABASE_URL is not set")
+	}
+
+	store, err := storage.New(self.T().Context(), self.cfg.DatabaseURL, 1, 0,
+		time.Minute)
+	self.Require().NoError(err)
+	self.T().Cleanup(func() { store.Close(context.Background()) })
+	return store
+}
+
+func (self *EndpointTestSuite) TestPreviewFeedFilterEndpoint() {
+	feedID := self.createFeed()
+
+	preview, err := self.client.PreviewFeedFilter(self.T().Context(), feedID,
+		&model.FilterPreviewRequest{BlockFilterEntryRules: new("any=.")})
+	self.Require().NoError(err)
+	self.Require().NotNil(preview)
+	self.Require().NotEmpty(preview.Entries)
+	self.Zero(preview.Kept)
+	self.Equal(len(preview.Entries), preview.Blocked+preview.MarkedRead)
+
+	preview, err = self.client.PreviewFeedFilter(self.T().Context(), feedID,
+		&model.FilterPreviewRequest{BlockFilterEntryRules: new("")})
+	self.Require().NoError(err)
+	self.Require().NotNil(preview)
+	self.Equal(len(preview.Entries), preview.Kept)
+
+	_, err = self.client.PreviewFeedFilter(self.T().Context(), feedID,
+		&model.FilterPreviewRequest{BlockFilterEntryRules: new("title=[a-z")})
+	self.T().Log(err)
+	self.Require().Error(err)
+}
+
+func (self *EndpointTestSuite) TestApplyFeedFiltersEndpoint() {
+	ctx := self.T().Context()
+	feedID := self.createFeed()
+
+	changed, err := self.client.ApplyFeedFilters(ctx, feedID)
+	self.Require().NoError(err)
+	self.Zero(changed)
+
+	_, err = self.client.UpdateFeed(feedID,
+		&model.FeedModificationRequest{BlockFilterEntryRules: new("any=.")})
+	self.Require().NoError(err)
+
+	blocked, err := self.client.ApplyFeedFilters(ctx, feedID)
+	self.Require().NoError(err)
+	self.Positive(blocked)
+
+	changed, err = self.client.ApplyAllFilters(ctx)
+	self.Require().NoError(err)
+	self.Zero(changed)
+
+	_, err = self.client.ApplyCategoryFilters(ctx, 0)
+	self.Require().Error(err)
+
+	hits, err := self.client.FeedFilterRuleHits(ctx, feedID)
+	self.Require().NoError(err)
+	self.Require().Len(hits, 1)
+	self.Equal(feedID, hits[0].FeedID)
+	self.Equal(model.FilterActionBlock, hits[0].Action)
+	self.Equal("any=.", hits[0].Rule)
+	self.Equal(int64(blocked), hits[0].Hits)
+
+	hits, err = self.client.FilterRuleHits(ctx)
+	self.Require().NoError(err)
+	self.Require().NotEmpty(hits)
+	i := slices.IndexFunc(hits, func(h model.FilterRuleHit) bool {
+		return h.FeedID == feedID
+	})
+	self.Require().GreaterOrEqual(i, 0)
+	self.Equal("any=.", hits[i].Rule)
+	self.Equal(int64(blocked), hits[i].Hits)
+
+	_, err = self.client.FeedFilterRuleHits(ctx, 0)
+	self.Require().Error(err)
+}
+
 func (self *EndpointTestSuite) TestMarkFeedAsReadEndpoint() {
 	feedID := self.createFeed()
 	self.Require().NoError(self.client.MarkFeedAsRead(feedID))
@@ -879,6 +1114,61 @@ func (self *EndpointTestSuite) TestDeleteFeedEndpoint() {
 	self.Require().NoError(err)
 }
 
+func (self *EndpointTestSuite) TestUpdateFeedsEndpoint() {
+	ctx := self.T().Context()
+	category := self.createCategory()
+	feedIDs := []int64{
+		self.createFeed(),
+		self.createFeedWith(model.FeedCreationRequest{
+			FeedURL: self.makeFeedURL("/2entries.xml").String(),
+		}),
+	}
+
+	feeds, err := self.client.UpdateFeeds(ctx, feedIDs,
+		&model.FeedModificationRequest{
+			CategoryID: &category.ID,
+			Disabled:   new(true),
+		})
+	self.Require().NoError(err)
+	self.Require().Len(feeds, len(feedIDs))
+	for _, feed := range feeds {
+		self.Equal(category.ID, feed.Category.ID)
+		self.True(feed.Disabled)
+	}
+
+	_, err = self.client.UpdateFeeds(ctx, feedIDs,
+		&model.FeedModificationRequest{Title: new("Same title")})
+	self.T().Log(err)
+	self.Require().Error(err)
+
+	_, err = self.client.UpdateFeeds(ctx, append(feedIDs, 0),
+		&model.FeedModificationRequest{Disabled: new(false)})
+	self.Require().ErrorIs(err, client.ErrNotFound)
+	for _, id := range feedIDs {
+		feed, err := self.client.Feed(id)
+		self.Require().NoError(err)
+		self.True(feed.Disabled, "feeds must not be changed")
+	}
+}
+
+func (self *EndpointTestSuite) TestRemoveFeedsEndpoint() {
+	ctx := self.T().Context()
+	feedIDs := []int64{
+		self.createFeed(),
+		self.createFeedWith(model.FeedCreationRequest{
+			FeedURL: self.makeFeedURL("/2entries.xml").String(),
+		}),
+	}
+
+	self.Require().ErrorIs(self.client.RemoveFeeds(ctx, append(feedIDs, 0)),
+		client.ErrNotFound)
+	self.Require().NoError(self.client.RemoveFeeds(ctx, feedIDs))
+
+	feeds, err := self.client.Feeds()
+	self.Require().NoError(err)
+	self.Empty(feeds)
+}
+
 func (self *EndpointTestSuite) TestRefreshAllFeedsEndpoint() {
 	self.createFeed()
 	self.Require().NoError(self.client.RefreshAllFeeds())
@@ -1526,6 +1816,39 @@ func (self *EndpointTestSuite) TestUpdateEntriesStarredEndpoint() {
 	self.False(entry.Starred, "Expected entry to no longer be starred")
 }
 
+func (self *EndpointTestSuite) TestEventsEndpoint() {
+	feedID := self.createFeed()
+	result, err := self.client.FeedEntries(feedID, nil)
+	self.Require().NoError(err, "Failed to get entries")
+	self.Require().NotNil(result)
+	self.Require().NotEmpty(result.Entries)
+	entryID := result.Entries[0].ID
+
+	ctx, cancel := context.WithTimeout(self.T().Context(), 10*time.Second)
+	defer cancel()
+
+	// The stream is subscribed asynchronously, keep changing the entry until
+	// its event is received.
+	go func() {
+		for ctx.Err() == nil {
+			_ = self.client.UpdateEntriesContext(ctx, []int64{entryID},
+				model.EntryStatusRead)
+			time.Sleep(100 * time.Millisecond)
+		}
+	}()
+
+	var received *model.Event
+	for event, err := range self.client.Events(ctx) {
+		self.Require().NoError(err)
+		received = event
+		break
+	}
+	self.Require().NotNil(received)
+	self.Equal(model.EventEntriesStatus, received.Type)
+	self.Equal(model.EntryStatusRead, received.Status)
+	self.Equal([]int64{entryID}, received.EntryIDs)
+}
+
 func (self *EndpointTestSuite) TestUpdateEntryEndpoint() {
 	feedID := self.createFeed()
 	result, err := self.client.FeedEntries(feedID, nil)
@@ -1567,6 +1890,361 @@ func (self *EndpointTestSuite) TestToggleBookmarkEndpoint() {
 	self.True(entry.Starred, "The entry should be bookmarked")
 }
 
+func (self *EndpointTestSuite) TestLabelsEndpoints() {
+	ctx := self.T().Context()
+	feedID := self.createFeed()
+	result, err := self.client.FeedEntries(feedID, &client.Filter{Limit: 1})
+	self.Require().NoError(err, "Failed to get entries")
+	self.Require().NotEmpty(result.Entries)
+	entryID := result.Entries[0].ID
+
+	label, err := self.client.CreateLabel(ctx, "Triage")
+	self.Require().NoError(err)
+	self.Equal("Triage", label.Title)
+
+	_, err = self.client.CreateLabel(ctx, "triage")
+	self.Require().Error(err, "Duplicated labels should be rejected")
+
+	_, err = self.client.CreateLabel(ctx, " ")
+	self.Require().Error(err, "Empty labels should be rejected")
+
+	label, err = self.client.UpdateLabel(ctx, label.ID, "To read")
+	self.Require().NoError(err)
+	self.Equal("To read", label.Title)
+
+	self.Require().NoError(self.client.SetEntryLabels(ctx, entryID,
+		[]int64{label.ID}))
+
+	labels, err := self.client.EntryLabels(ctx, entryID)
+	self.Require().NoError(err)
+	self.Require().Len(labels, 1)
+	self.Equal(label.ID, labels[0].ID)
+
+	labels, err = self.client.Labels(ctx)
+	self.Require().NoError(err)
+	self.Require().Len(labels, 1)
+	self.Equal(1, labels[0].EntryCount)
+
+	entries, err := self.client.Entries(&client.Filter{LabelID: label.ID})
+	self.Require().NoError(err)
+	self.Equal(1, entries.Total)
+	self.Equal(entryID, entries.Entries[0].ID)
+
+	self.Require().NoError(self.client.SetEntryLabels(ctx, entryID, nil))
+	labels, err = self.client.EntryLabels(ctx, entryID)
+	self.Require().NoError(err)
+	self.Empty(labels)
+
+	self.Require().NoError(self.client.DeleteLabel(ctx, label.ID))
+	self.Require().Error(self.client.DeleteLabel(ctx, label.ID))
+}
+
+func (self *EndpointTestSuite) TestSavedSearchesEndpoints() {
+	ctx := self.T().Context()
+	feedURL := self.makeFeedURL("/2entries.xml")
+	self.createFeedWith(model.FeedCreationRequest{FeedURL: feedURL.String()})
+
+	search, err := self.client.CreateSavedSearch(ctx, "Releases",
+		`title:"v2.2.8:"`)
+	self.Require().NoError(err)
+	self.Equal("Releases", search.Title)
+	self.NotEmpty(search.Token)
+
+	_, err = self.client.CreateSavedSearch(ctx, "releases", "log")
+	self.Require().Error(err, "Duplicated saved searches should be rejected")
+
+	_, err = self.client.CreateSavedSearch(ctx, "Invalid", "is:everything")
+	self.Require().Error(err, "Invalid queries should be rejected")
+
+	_, err = self.client.CreateSavedSearch(ctx, "Empty", " ")
+	self.Require().Error(err, "Empty queries should be rejected")
+
+	searches, err := self.client.SavedSearches(ctx)
+	self.Require().NoError(err)
+	self.Require().Len(searches, 1)
+	self.Equal(1, searches[0].UnreadCount)
+
+	result, err := self.client.SavedSearchEntries(ctx, search.ID, nil)
+	self.Require().NoError(err)
+	self.Require().Equal(1, result.Total)
+	self.Equal("v2.2.8: Miniflux 2.2.8", result.Entries[0].Title)
+
+	search, err = self.client.UpdateSavedSearch(ctx, search.ID, "GitHub",
+		"domain:github.com")
+	self.Require().NoError(err)
+	self.Equal("GitHub", search.Title)
+
+	result, err = self.client.SavedSearchEntries(ctx, search.ID,
+		&client.Filter{Search: "is:unread"})
+	self.Require().NoError(err)
+	self.Equal(2, result.Total)
+
+	self.Require().NoError(self.client.MarkSavedSearchAsRead(ctx, search.ID))
+	searches, err = self.client.SavedSearches(ctx)
+	self.Require().NoError(err)
+	self.Require().Len(searches, 1)
+	self.Zero(searches[0].UnreadCount)
+
+	self.Require().NoError(self.client.DeleteSavedSearch(ctx, search.ID))
+	self.Require().Error(self.client.DeleteSavedSearch(ctx, search.ID))
+}
+
+func (self *EndpointTestSuite) TestGoogleReaderSavedSearchByCategory() {
+	ctx := self.T().Context()
+	category, err := self.client.CreateCategory("Releases")
+	self.Require().NoError(err)
+	self.createFeedWith(model.FeedCreationRequest{
+		FeedURL:    self.makeFeedURL("/2entries.xml").String(),
+		CategoryID: category.ID,
+	})
+
+	_, err = self.client.CreateSavedSearch(ctx, "Saved", "category:releases")
+	self.Require().NoError(err)
+
+	token := self.googleReaderLogin()
+	ids := self.googleReaderItemIDs(token, "user/-/label/Saved")
+	self.Len(ids, 2)
+}
+
+func (self *EndpointTestSuite) TestGoogleReaderFolderLabelCollision() {
+	ctx := self.T().Context()
+	label, err := self.client.CreateLabel(ctx, "Releases")
+	self.Require().NoError(err)
+
+	category, err := self.client.CreateCategory("Releases")
+	self.Require().NoError(err)
+	feedID := self.createFeedWith(model.FeedCreationRequest{
+		FeedURL:    self.makeFeedURL("/2entries.xml").String(),
+		CategoryID: category.ID,
+	})
+
+	_, err = self.client.CreateLabel(ctx, "releases")
+	self.Require().Error(err, "Labels of category titles should be rejected")
+
+	results, err := self.client.FeedEntries(feedID, nil)
+	self.Require().NoError(err)
+	self.Require().Len(results.Entries, 2)
+	entryID := results.Entries[0].ID
+	self.Require().NoError(self.client.SetEntryLabels(ctx, entryID,
+		[]int64{label.ID}))
+
+	token := self.googleReaderLogin()
+	ids := self.googleReaderItemIDs(token, "user/-/label/Releases")
+	self.Len(ids, 2, "The category should win over the label")
+
+	status := self.googleReaderPost(token, "/edit-tag", url.Values{
+		"i": {strconv.FormatInt(results.Entries[1].ID, 10)},
+		"a": {"user/-/label/Releases"},
+	})
+	self.Equal(http.StatusBadRequest, status,
+		"Entries shouldn't be tagged with a folder")
+
+	status = self.googleReaderPost(token, "/mark-all-as-read", url.Values{
+		"s": {"user/-/label/Releases"},
+	})
+	self.Require().Equal(http.StatusOK, status)
+	self.checkFeedIsRead(feedID)
+}
+
+// googleReaderLogin enables the Google Reader API for the test user and
+// returns its auth token.
+func (self *EndpointTestSuite) googleReaderLogin() string {
+	self.T().Helper()
+
+	jar, err := cookiejar.New(nil)
+	self.Require().NoError(err)
+	c := &http.Client{Jar: jar}
+	post := func(c *http.Client, path string, form url.Values) []byte {
+		self.T().Helper()
+		resp, err := c.PostForm(self.cfg.BaseURL+path, form)
+		self.Require().NoError(err)
+		defer resp.Body.Close()
+		self.Require().Equal(http.StatusOK, resp.StatusCode, path)
+		b, err := io.ReadAll(resp.Body)
+		self.Require().NoError(err)
+		return b
+	}
+
+	post(c, "/login", url.Values{
+		"username": {self.user.Username},
+		"password": {self.cfg.RegularPassword},
+	})
+	post(c, "/integration", url.Values{
+		"googlereader_enabled":  {"1"},
+		"googlereader_password": {self.cfg.RegularPassword},
+	})
+
+	b := post(http.DefaultClient, "/accounts/ClientLogin", url.Values{
+		"Email":  {self.user.Username},
+		"Passwd": {self.cfg.RegularPassword},
+	})
+	for line := range strings.Lines(string(b)) {
+		if token, ok := strings.CutPrefix(line, "Auth="); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	self.FailNow("Google Reader auth token not found", string(b))
+	return ""
+}
+
+// googleReaderItemIDs returns IDs of items of the Google Reader stream.
+func (self *EndpointTestSuite) googleReaderItemIDs(token, stream string,
+) []string {
+	self.T().Helper()
+
+	q := url.Values{"s": {stream}, "n": {"100"}, "output": {"json"}}
+	req, err := http.NewRequestWithContext(self.T().Context(), http.MethodGet,
+		self.cfg.BaseURL+"/reader/api/0/stream/items/ids?"+q.Encode(), nil)
+	self.Require().NoError(err)
+	req.Header.Set("Authorization", "GoogleLogin auth="+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	self.Require().NoError(err)
+	defer resp.Body.Close()
+	self.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		ItemRefs []struct {
+			ID string `json:"id"`
+		} `json:"itemRefs"`
+	}
+	self.Require().NoError(json.NewDecoder(resp.Body).Decode(&result))
+
+	ids := make([]string, len(result.ItemRefs))
+	for i, ref := range result.ItemRefs {
+		ids[i] = ref.ID
+	}
+	return ids
+}
+
+// googleReaderPost posts form to the Google Reader API path with the CSRF
+// token of the session and returns the status code.
+func (self *EndpointTestSuite) googleReaderPost(token, path string,
+	form url.Values,
+) int {
+	self.T().Helper()
+
+	do := func(method, path string, body io.Reader) (int, []byte) {
+		self.T().Helper()
+		req, err := http.NewRequestWithContext(self.T().Context(), method,
+			self.cfg.BaseURL+"/reader/api/0"+path, body)
+		self.Require().NoError(err)
+		req.Header.Set("Authorization", "GoogleLogin auth="+token)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		self.Require().NoError(err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		self.Require().NoError(err)
+		return resp.StatusCode, b
+	}
+
+	status, b := do(http.MethodGet, "/token", nil)
+	self.Require().Equal(http.StatusOK, status)
+	form.Set("T", strings.TrimSpace(string(b)))
+
+	status, _ = do(http.MethodPost, path, strings.NewReader(form.Encode()))
+	return status
+}
+
+func (self *EndpointTestSuite) TestSiteRulesEndpoints() {
+	ctx := self.T().Context()
+	rule, err := self.client.CreateSiteRule(ctx, &model.SiteRuleRequest{
+		Hostname:     " Example.COM ",
+		ScraperRules: "article",
+	})
+	self.Require().NoError(err)
+	self.Equal("example.com", rule.Hostname)
+	self.Equal(self.user.ID, rule.UserID)
+
+	_, err = self.client.CreateSiteRule(ctx, &model.SiteRuleRequest{
+		Hostname: "example.com", UserAgent: "Test",
+	})
+	self.Require().Error(err, "Duplicated site rules should be rejected")
+
+	_, err = self.client.CreateSiteRule(ctx, &model.SiteRuleRequest{
+		Hostname: "example.org", ScraperRules: "div[",
+	})
+	self.Require().Error(err, "Invalid scraper rules should be rejected")
+
+	_, err = self.client.CreateSiteRule(ctx, &model.SiteRuleRequest{
+		Hostname: "example.org", UserAgent: "Test", Global: true,
+	})
+	self.Require().Error(err, "Only admins can create global site rules")
+
+	globalHostname := self.user.Username + ".example.net"
+	global, err := self.admin.CreateSiteRule(ctx, &model.SiteRuleRequest{
+		Hostname: globalHostname, Referer: "https://example.net/", Global: true,
+	})
+	self.Require().NoError(err)
+	self.True(global.Global())
+	defer func() {
+		self.Require().NoError(self.admin.DeleteSiteRule(ctx, global.ID))
+	}()
+
+	rules, err := self.client.SiteRules(ctx)
+	self.Require().NoError(err)
+	hostnames := make([]string, len(rules))
+	for i := range rules {
+		hostnames[i] = rules[i].Hostname
+	}
+	self.Contains(hostnames, "example.com")
+	self.Contains(hostnames, globalHostname)
+
+	_, err = self.client.UpdateSiteRule(ctx, global.ID,
+		&model.SiteRuleRequest{Hostname: globalHostname, UserAgent: "Test"})
+	self.Require().Error(err, "Only admins can update global site rules")
+
+	rule, err = self.client.UpdateSiteRule(ctx, rule.ID,
+		&model.SiteRuleRequest{Hostname: "example.com", UserAgent: "Test"})
+	self.Require().NoError(err)
+	self.Equal("Test", rule.UserAgent)
+	self.Empty(rule.ScraperRules)
+
+	exported, err := self.client.ExportSiteRules(ctx, false)
+	self.Require().NoError(err)
+	self.Contains(string(exported), "hostname: example.com")
+	self.NotContains(string(exported), globalHostname)
+
+	imported, err := self.client.ImportSiteRules(ctx, false,
+		io.NopCloser(strings.NewReader(`
+- hostname: example.com
+  scraper_rules: main
+- hostname: example.org
+  rewrite_rules: add_image_title`)))
+	self.Require().NoError(err)
+	self.Equal(2, imported)
+
+	_, err = self.client.ImportSiteRules(ctx, true,
+		io.NopCloser(strings.NewReader("- hostname: example.com\n  user_agent: Test")))
+	self.Require().Error(err, "Only admins can import global site rules")
+
+	_, err = self.client.ImportSiteRules(ctx, false,
+		io.NopCloser(strings.NewReader("- hostname: example.com")))
+	self.Require().Error(err, "Invalid documents should be rejected")
+
+	rules, err = self.client.SiteRules(ctx)
+	self.Require().NoError(err)
+	var own model.SiteRules
+	for _, r := range rules {
+		if !r.Global() {
+			own = append(own, r)
+		}
+	}
+	self.Require().Len(own, 2)
+	self.Equal(rule.ID, own[0].ID)
+	self.Equal("main", own[0].ScraperRules)
+	self.Empty(own[0].UserAgent, "Imported site rules replace existing ones")
+	self.Equal("add_image_title", own[1].RewriteRules)
+
+	self.Require().Error(self.client.DeleteSiteRule(ctx, global.ID))
+	self.Require().NoError(self.client.DeleteSiteRule(ctx, rule.ID))
+	self.Require().Error(self.client.DeleteSiteRule(ctx, rule.ID))
+}
+
 func (self *EndpointTestSuite) TestSaveEntryEndpoint() {
 	feedID := self.createFeed()
 	result, err := self.client.FeedEntries(feedID, &client.Filter{Limit: 1})
@@ -1598,6 +2276,37 @@ func (self *EndpointTestSuite) TestFetchContentEndpoint() {
 	self.NotEmpty(content, "Invalid content")
 }
 
+func (self *EndpointTestSuite) TestScraperDiagnosticsEndpoints() {
+	feedID := self.createFeed()
+	result, err := self.client.FeedEntries(feedID, &client.Filter{Limit: 1})
+	self.Require().NoError(err, "Failed to get entries")
+	self.Require().NotEmpty(result.Entries)
+	entry := result.Entries[0]
+
+	ctx := self.T().Context()
+	d, err := self.client.EntryScraperDiagnostics(ctx, entry.ID)
+	self.Require().NoError(err)
+	self.Equal(entry.ID, d.EntryID)
+	self.Equal(entry.URL, d.URL)
+	if d.Error == "" {
+		self.NotEmpty(d.Method)
+		self.Positive(d.HTMLSize)
+		self.Positive(d.Pages)
+	}
+
+	d, err = self.client.ScraperDiagnostics(ctx, entry.URL)
+	self.Require().NoError(err)
+	self.Zero(d.EntryID)
+	self.Zero(d.OriginalTextLength)
+	self.False(d.Fallback)
+
+	_, err = self.client.ScraperDiagnostics(ctx, "example.org/article")
+	self.Require().Error(err, "Relative URLs should be rejected")
+
+	_, err = self.client.EntryScraperDiagnostics(ctx, 0)
+	self.Require().Error(err, "Unknown entries should be rejected")
+}
+
 func (self *EndpointTestSuite) TestFlushHistoryEndpoint() {
 	feedID := self.createFeed()
 	result, err := self.client.FeedEntries(feedID, &client.Filter{Limit: 3})
@@ -1669,6 +2378,185 @@ func (self *EndpointTestSuite) TestImportEntries() {
 	self.True(res.Entries[0].Starred)
 }
 
+func (self *EndpointTestSuite) TestImportEntriesFrom() {
+	ctx := self.T().Context()
+	f, err := os.Open("testdata/feed.xml")
+	self.Require().NoError(err)
+
+	feedURL := "https://example.org/imported/feed.xml"
+	res, err := self.client.ImportEntriesFrom(ctx, f,
+		&client.ImportEntriesOptions{FeedURL: feedURL, Starred: true})
+	self.Require().NoError(err)
+	self.Require().NotNil(res)
+	self.Require().NotEmpty(res.Entries)
+	self.Equal(len(res.Entries), res.Total)
+	for _, e := range res.Entries {
+		self.Equal(model.EntryStatusRead, e.Status)
+		self.True(e.Starred)
+		self.Less(e.Date.Year(), 2026)
+	}
+
+	feeds, err := self.client.Feeds()
+	self.Require().NoError(err)
+	i := slices.IndexFunc(feeds, func(f *model.Feed) bool {
+		return f.FeedURL == feedURL
+	})
+	self.Require().NotEqual(-1, i)
+	self.Equal("Miniflux", feeds[i].Title)
+
+	export := `{
+  "id": "user/1/state/com.google/starred",
+  "items": [{
+    "id": "tag:google.com,2005:reader/item/1",
+    "categories": ["user/1/state/com.google/reading-list",
+                   "user/1/label/Archive"],
+    "title": "Exported <b>entry</b>",
+    "published": 1262304000,
+    "alternate": [{"href": "https://example.org/exported/1"}],
+    "summary": {"content": "<p>Hello</p><script>alert(1)</script>"},
+    "origin": {
+      "streamId": "feed/https://example.org/exported/feed.xml",
+      "title": "Exported feed",
+      "htmlUrl": "https://example.org/exported/"
+    }
+  }]
+}`
+	res, err = self.client.ImportEntriesFrom(ctx,
+		io.NopCloser(strings.NewReader(export)), nil)
+	self.Require().NoError(err)
+	self.Require().NotNil(res)
+	self.Require().Len(res.Entries, 1)
+
+	entry := res.Entries[0]
+	self.Equal("Exported entry", entry.Title)
+	self.Equal("<p>Hello</p>", entry.Content)
+	self.Equal(model.EntryStatusUnread, entry.Status)
+	self.True(entry.Starred)
+	self.Equal(int64(1262304000), entry.Date.Unix())
+	self.Equal([]string{"Archive"}, entry.Tags)
+
+	_, err = self.client.ImportEntriesFrom(ctx,
+		io.NopCloser(strings.NewReader(
+			strings.Replace(export, "feed/https://", "feed/javascript://", 1))),
+		nil)
+	self.T().Log(err)
+	self.Require().Error(err)
+
+	f, err = os.Open("testdata/feed.xml")
+	self.Require().NoError(err)
+	_, err = self.client.ImportEntriesFrom(ctx, f,
+		&client.ImportEntriesOptions{FeedURL: "file:///etc/feed.xml"})
+	self.T().Log(err)
+	self.Require().Error(err)
+
+	_, err = self.client.ImportEntriesFrom(ctx,
+		io.NopCloser(strings.NewReader(`{"feed_url": "`+feedURL+`",`)), nil)
+	self.T().Log(err)
+	self.Require().Error(err)
+	self.Contains(err.Error(), "JSON")
+}
+
+func (self *EndpointTestSuite) TestExportImportAccount() {
+	ctx := self.T().Context()
+	feedID := self.createFeed()
+
+	entries, err := self.client.FeedEntries(feedID, nil)
+	self.Require().NoError(err)
+	self.Require().NotEmpty(entries.Entries)
+	entryID := entries.Entries[0].ID
+	self.Require().NoError(self.client.UpdateEntries([]int64{entryID},
+		model.EntryStatusRead))
+	self.Require().NoError(self.client.ToggleBookmark(entryID))
+
+	label, err := self.client.CreateLabel(ctx, "Later")
+	self.Require().NoError(err)
+	self.Require().NoError(self.client.SetEntryLabels(ctx, entryID,
+		[]int64{label.ID}))
+
+	_, err = self.client.CreateSavedSearch(ctx, "Releases", "title:miniflux")
+	self.Require().NoError(err)
+
+	b, err := self.client.ExportAccount(ctx)
+	self.Require().NoError(err)
+	self.Require().NotEmpty(b)
+
+	username := self.cfg.RandomUsername()
+	user, err := self.admin.CreateUser(username, self.cfg.RegularPassword, false)
+	self.Require().NoError(err)
+	self.T().Cleanup(func() { self.NoError(self.admin.DeleteUser(user.ID)) })
+	restored := client.NewClient(self.cfg.BaseURL, username,
+		self.cfg.RegularPassword)
+
+	result, err := restored.ImportAccount(ctx,
+		io.NopCloser(bytes.NewReader(b)))
+	self.Require().NoError(err)
+	self.Equal(1, result.Feeds)
+	self.Equal(1, result.Labels)
+	self.Equal(1, result.SavedSearches)
+	self.Equal(len(entries.Entries), result.Entries)
+
+	starred, err := restored.Entries(&client.Filter{
+		Starred: client.FilterOnlyStarred,
+	})
+	self.Require().NoError(err)
+	self.Require().Equal(1, starred.Total)
+	self.Equal(model.EntryStatusRead, starred.Entries[0].Status)
+	self.Equal(entries.Entries[0].Hash, starred.Entries[0].Hash)
+
+	labels, err := restored.EntryLabels(ctx, starred.Entries[0].ID)
+	self.Require().NoError(err)
+	self.Require().Len(labels, 1)
+	self.Equal("Later", labels[0].Title)
+
+	result, err = restored.ImportAccount(ctx,
+		io.NopCloser(bytes.NewReader(b)))
+	self.Require().NoError(err)
+	self.Zero(result.Feeds)
+	self.Zero(result.Labels)
+
+	_, err = restored.ImportAccount(ctx,
+		io.NopCloser(strings.NewReader(`{"version": 99}`)))
+	self.T().Log(err)
+	self.Require().Error(err)
+
+	// Nothing is restored from an archive with an invalid entry.
+	_, err = restored.ImportAccount(ctx, io.NopCloser(strings.NewReader(`{
+  "version": 1,
+  "categories": [{"title": "Partial"}],
+  "feeds": [{"feed_url": "https://example.org/partial.xml",
+             "category": {"title": "Partial"}}],
+  "entries": [{"hash": "partial", "status": "unknown",
+               "feed_url": "https://example.org/partial.xml"}]
+}`)))
+	self.T().Log(err)
+	self.Require().Error(err)
+
+	// Archived feeds are validated like feeds created by the API.
+	for _, feed := range [...]string{
+		`{"feed_url": "javascript:alert(1)"}`,
+		`{"feed_url": "https://example.org/partial.xml",
+		  "scraper_rules": "div["}`,
+		`{"feed_url": "https://example.org/partial.xml",
+		  "extra": {"block_filter_entry_rules": "unknown=rule"}}`,
+		`{"feed_url": "https://example.org/partial.xml",
+		  "extra": {"refresh_interval": -1}}`,
+	} {
+		_, err = restored.ImportAccount(ctx, io.NopCloser(strings.NewReader(`{
+  "version": 1,
+  "categories": [{"title": "Partial"}],
+  "feeds": [`+feed+`]
+}`)))
+		self.T().Log(err)
+		self.Require().Error(err)
+	}
+
+	categories, err := restored.Categories()
+	self.Require().NoError(err)
+	for _, c := range categories {
+		self.NotEqual("Partial", c.Title)
+	}
+}
+
 func (self *EndpointTestSuite) TestRefreshRemovedEntry() {
 	feedID := self.createFeedWith(model.FeedCreationRequest{
 		IgnoreHTTPCache: true,
@@ -14,6 +14,7 @@ import (
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/logging"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
 	"miniflux.app/v2/internal/validator"
 )
 
@@ -88,6 +89,31 @@ func (h *handler) markCategoryAsRead(w http.ResponseWriter, r *http.Request,
 	return nil
 }
 
+func (h *handler) applyCategoryFilters(w http.ResponseWriter, r *http.Request,
+) (*applyFiltersResponse, error) {
+	ctx := r.Context()
+	user := request.User(r)
+	id := request.RouteInt64Param(r, "categoryID")
+
+	category, err := h.store.Category(ctx, user.ID, id)
+	if err != nil {
+		return nil, err
+	} else if category == nil {
+		return nil, response.ErrNotFound
+	}
+
+	feeds, err := h.store.FeedsByCategory(ctx, user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := filter.FilterStoredEntries(ctx, h.store, user, feeds...)
+	if err != nil {
+		return nil, err
+	}
+	return &applyFiltersResponse{Changed: changed}, nil
+}
+
 func (h *handler) getCategories(w http.ResponseWriter, r *http.Request,
 ) (categories []model.Category, _ error) {
 	var err error
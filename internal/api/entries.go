@@ -4,8 +4,10 @@ import (
 	json_parser "encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -17,13 +19,26 @@ import (
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/readingtime"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
 	"miniflux.app/v2/internal/validator"
 )
 
 func (h *handler) importEntries(w http.ResponseWriter, r *http.Request,
 ) (*entriesResponse, error) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	switch detectImportFormat(b) {
+	case importFormatFeed:
+		return h.importFeedEntries(r, b)
+	case importFormatGoogleReader:
+		return h.importGoogleReaderEntries(r, b)
+	}
+
 	var importReq model.ImportEntries
-	if err := json_parser.NewDecoder(r.Body).Decode(&importReq); err != nil {
+	if err := json_parser.Unmarshal(b, &importReq); err != nil {
 		return nil, response.WrapBadRequest(err)
 	}
 
@@ -121,6 +136,7 @@ type entriesFinder struct {
 
 	feedID       int64
 	categoryID   int64
+	search       string
 	fetchContent bool
 }
 
@@ -134,6 +150,12 @@ func (self *entriesFinder) WithCategoryID(id int64) *entriesFinder {
 	return self
 }
 
+// WithSearch sets a search query combined with the search query parameter.
+func (self *entriesFinder) WithSearch(query string) *entriesFinder {
+	self.search = query
+	return self
+}
+
 func (self *entriesFinder) WithContent(v bool) *entriesFinder {
 	self.fetchContent = v
 	return self
@@ -164,6 +186,14 @@ func (self *entriesFinder) Entries(r *http.Request) (*entriesResponse, error) {
 		return nil, response.WrapBadRequest(err)
 	}
 
+	search, err := model.ParseSearchQuery(
+		strings.TrimSpace(self.search+" "+
+			request.QueryStringParam(r, "search", "")),
+		timezone.Location(request.User(r).Timezone))
+	if err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
 	g, ctx := errgroup.WithContext(r.Context())
 	errInvalid := errors.New("invalid")
 
@@ -194,16 +224,31 @@ func (self *entriesFinder) Entries(r *http.Request) (*entriesResponse, error) {
 		})
 	}
 
+	labelID := request.QueryInt64Param(r, "label_id", 0)
+	if labelID > 0 {
+		g.Go(func() error {
+			label, err := self.store.LabelByID(ctx, userID, labelID)
+			if err != nil {
+				return err
+			} else if label == nil {
+				return fmt.Errorf("%w label ID", errInvalid)
+			}
+			return nil
+		})
+	}
+
 	b := self.store.NewEntryQueryBuilder(userID).
 		WithFeedID(feedID).
 		WithCategoryID(categoryID).
+		WithLabelID(labelID).
 		WithStatuses(statuses).
 		WithSorting(order, direction).
 		WithOffset(offset).
 		WithLimit(limit).
 		WithTags(request.QueryStringParamList(r, "tags")).
 		WithContent(self.fetchContent).
-		WithoutStatus(model.EntryStatusRemoved)
+		WithoutStatus(model.EntryStatusRemoved).
+		WithSearch(search)
 	self.filter(b, r)
 
 	var entries model.Entries
@@ -293,9 +338,4 @@ func (self *entriesFinder) filter(b *storage.EntryQueryBuilder, r *http.Request,
 			b.WithStarred(starred)
 		}
 	}
-
-	searchQuery := request.QueryStringParam(r, "search", "")
-	if searchQuery != "" {
-		b.WithSearchQuery(searchQuery)
-	}
 }
@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/urllib"
+	"miniflux.app/v2/internal/validator"
+)
+
+const (
+	importFormatEntries = iota
+	importFormatFeed
+	importFormatGoogleReader
+)
+
+// detectImportFormat returns the format of an entries import: the
+// model.ImportEntries JSON, an Atom, RSS or JSON Feed document, or a Google
+// Reader stream export, like starred.json of Google Takeout or Inoreader.
+func detectImportFormat(b []byte) int {
+	var probe struct {
+		FeedURL string          `json:"feed_url"`
+		Entries json.RawMessage `json:"entries"`
+		Version string          `json:"version"`
+		Items   json.RawMessage `json:"items"`
+	}
+
+	switch {
+	case json.Unmarshal(b, &probe) != nil:
+		// Atom and RSS documents are XML, so malformed JSON is reported as a
+		// JSON error of an entries import.
+		if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 &&
+			(trimmed[0] == '{' || trimmed[0] == '[') {
+			return importFormatEntries
+		}
+		return importFormatFeed
+	case probe.FeedURL != "" || probe.Entries != nil:
+		return importFormatEntries
+	case probe.Version != "":
+		return importFormatFeed
+	case probe.Items != nil:
+		return importFormatGoogleReader
+	}
+	return importFormatEntries
+}
+
+// importFeedEntries imports entries of a feed document. The feed_url query
+// parameter overrides the URL of the feed, status and starred set the state of
+// imported entries, which are read by default.
+func (h *handler) importFeedEntries(r *http.Request, b []byte,
+) (*entriesResponse, error) {
+	status := request.QueryStringParam(r, "status", model.EntryStatusRead)
+	if err := validator.ValidateEntryStatus(status); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+	starred := request.QueryBoolParam(r, "starred", false)
+
+	feedURL := request.QueryStringParam(r, "feed_url", "")
+	parsed, err := parser.ParseBytes(feedURL, b)
+	if err != nil {
+		return nil, response.WrapBadRequest(err)
+	} else if feedURL == "" {
+		feedURL = parsed.FeedURL
+	}
+
+	if feedURL == "" {
+		return nil, response.WrapBadRequest(errors.New("empty feed URL"))
+	} else if len(parsed.Entries) == 0 {
+		return nil, response.WrapBadRequest(errors.New("empty entries list"))
+	}
+
+	feed, err := h.importFeed(r, &model.Feed{
+		Title:   parsed.Title,
+		FeedURL: feedURL,
+		SiteURL: parsed.SiteURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range parsed.Entries {
+		entry.WithImportedState(status, starred)
+	}
+	return h.storeImportedEntries(r, feed, parsed.Entries)
+}
+
+type googleReaderExport struct {
+	ID    string             `json:"id"`
+	Items []googleReaderItem `json:"items"`
+}
+
+type googleReaderItem struct {
+	ID         string             `json:"id"`
+	Categories []string           `json:"categories"`
+	Title      string             `json:"title"`
+	Published  int64              `json:"published"`
+	Author     string             `json:"author"`
+	Alternate  []googleReaderHREF `json:"alternate"`
+	Canonical  []googleReaderHREF `json:"canonical"`
+	Summary    googleReaderText   `json:"summary"`
+	Content    googleReaderText   `json:"content"`
+	Origin     googleReaderOrigin `json:"origin"`
+}
+
+type googleReaderHREF struct {
+	HREF string `json:"href"`
+}
+
+type googleReaderText struct {
+	Content string `json:"content"`
+}
+
+type googleReaderOrigin struct {
+	StreamID string `json:"streamId"`
+	Title    string `json:"title"`
+	HTMLURL  string `json:"htmlUrl"`
+}
+
+// importGoogleReaderEntries imports items of a Google Reader stream export
+// into the feeds of their origin, which are created if needed. Read and
+// starred states come from the item categories, items of a starred stream
+// are starred.
+func (h *handler) importGoogleReaderEntries(r *http.Request, b []byte,
+) (*entriesResponse, error) {
+	var export googleReaderExport
+	if err := json.Unmarshal(b, &export); err != nil {
+		return nil, response.WrapBadRequest(err)
+	} else if len(export.Items) == 0 {
+		return nil, response.WrapBadRequest(errors.New("empty entries list"))
+	}
+
+	starredStream := strings.HasSuffix(export.ID, "/state/com.google/starred")
+	var origins []googleReaderOrigin
+	byOrigin := make(map[string][]*model.Entry)
+	for i := range export.Items {
+		item := &export.Items[i]
+		feedURL, ok := strings.CutPrefix(item.Origin.StreamID, "feed/")
+		if !ok || feedURL == "" {
+			return nil, response.WrapBadRequest(fmt.Errorf(
+				"item %q: invalid origin stream %q", item.ID, item.Origin.StreamID))
+		}
+
+		entry := item.entry()
+		if entry == nil {
+			continue
+		}
+
+		if _, ok := byOrigin[feedURL]; !ok {
+			origins = append(origins, item.Origin)
+		}
+		if starredStream {
+			entry.WithImportedState(entry.Status, true)
+		}
+		byOrigin[feedURL] = append(byOrigin[feedURL], entry)
+	}
+
+	result := &entriesResponse{Entries: model.Entries{}}
+	for _, origin := range origins {
+		feedURL := strings.TrimPrefix(origin.StreamID, "feed/")
+		feed, err := h.importFeed(r, &model.Feed{
+			Title:   origin.Title,
+			FeedURL: feedURL,
+			SiteURL: origin.HTMLURL,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		imported, err := h.storeImportedEntries(r, feed, byOrigin[feedURL])
+		if err != nil {
+			return nil, err
+		}
+		result.Entries = append(result.Entries, imported.Entries...)
+	}
+	result.Total = len(result.Entries)
+	return result, nil
+}
+
+// entry returns the entry of the item or nil, if the item has no URL.
+func (self *googleReaderItem) entry() *model.Entry {
+	var entryURL string
+	for _, links := range [...][]googleReaderHREF{
+		self.Alternate, self.Canonical,
+	} {
+		if len(links) > 0 && links[0].HREF != "" {
+			entryURL = links[0].HREF
+			break
+		}
+	}
+	if entryURL == "" {
+		return nil
+	}
+
+	ext := &model.ExternalEntry{
+		Status:      model.EntryStatusRead,
+		Title:       self.Title,
+		URL:         entryURL,
+		PublishedAt: self.Published,
+		Content:     self.Content.Content,
+		Author:      self.Author,
+	}
+	if ext.Content == "" {
+		ext.Content = self.Summary.Content
+	}
+
+	var read, unread bool
+	for _, c := range self.Categories {
+		switch {
+		case strings.HasSuffix(c, "/state/com.google/read"):
+			read = true
+		case strings.HasSuffix(c, "/state/com.google/kept-unread"),
+			strings.HasSuffix(c, "/state/com.google/reading-list"):
+			unread = true
+		case strings.HasSuffix(c, "/state/com.google/starred"):
+			ext.Starred = true
+		case strings.Contains(c, "/label/"):
+			_, label, _ := strings.Cut(c, "/label/")
+			ext.Tags = append(ext.Tags, label)
+		}
+	}
+	if unread && !read {
+		ext.Status = model.EntryStatusUnread
+	}
+
+	return model.NewEntryFrom(ext)
+}
+
+// importFeed returns the feed of the user with the URL of the given feed. The
+// feed is created in the category of the category_id query parameter or in
+// the first category, if it doesn't exist.
+func (h *handler) importFeed(r *http.Request, feed *model.Feed,
+) (*model.Feed, error) {
+	if !urllib.IsAbsoluteURL(feed.FeedURL) {
+		return nil, response.WrapBadRequest(
+			fmt.Errorf("invalid feed URL %q", feed.FeedURL))
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	found, err := h.store.FeedByURL(ctx, userID, feed.FeedURL)
+	if err != nil {
+		return nil, err
+	} else if found != nil {
+		return found, nil
+	}
+
+	category, err := h.importCategory(ctx, userID,
+		request.QueryInt64Param(r, "category_id", 0))
+	if err != nil {
+		return nil, err
+	}
+
+	feed.UserID, feed.Category = userID, category
+	if feed.Title == "" {
+		feed.Title = feed.FeedURL
+	}
+	if !urllib.IsAbsoluteURL(feed.SiteURL) {
+		feed.SiteURL = feed.FeedURL
+	}
+
+	if err := h.store.CreateFeed(ctx, feed); err != nil {
+		return nil, fmt.Errorf("api: create imported feed %q: %w",
+			feed.FeedURL, err)
+	}
+	return feed, nil
+}
+
+func (h *handler) importCategory(ctx context.Context, userID,
+	categoryID int64,
+) (*model.Category, error) {
+	if categoryID == 0 {
+		return h.store.FirstCategory(ctx, userID)
+	}
+
+	category, err := h.store.Category(ctx, userID, categoryID)
+	if err != nil {
+		return nil, err
+	} else if category == nil {
+		return nil, response.WrapBadRequest(
+			errors.New("category does not exists"))
+	}
+	return category, nil
+}
+
+// storeImportedEntries sanitizes the given entries and stores them in the
+// feed, keeping their state and timestamps.
+func (h *handler) storeImportedEntries(r *http.Request, feed *model.Feed,
+	entries model.Entries,
+) (*entriesResponse, error) {
+	user := request.User(r)
+	for _, entry := range entries {
+		entry.Feed = feed
+		if err := processor.UpdateEntry(user, entry); err != nil {
+			return nil, response.WrapBadRequest(err)
+		}
+	}
+
+	_, err := h.store.StoreFeedEntries(r.Context(), user.ID, feed.ID, entries,
+		true)
+	if err != nil {
+		return nil, err
+	}
+	return &entriesResponse{Total: len(entries), Entries: entries}, nil
+}
@@ -189,7 +189,7 @@ func (h *handler) fetchContent(w http.ResponseWriter, r *http.Request,
 
 	user := request.User(r)
 	if request.QueryBoolParam(r, "update_content", false) {
-		err := processor.ProcessEntryWebPage(ctx, feed, entry, user)
+		err := processor.ProcessEntryWebPage(ctx, h.store, feed, entry, user)
 		if err != nil {
 			return nil, err
 		}
@@ -201,7 +201,7 @@ func (h *handler) fetchContent(w http.ResponseWriter, r *http.Request,
 		entry.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router,
 			entry.Content)
 	} else {
-		err := processor.ProcessEntryWebPage(ctx, feed, entry, user,
+		err := processor.ProcessEntryWebPage(ctx, h.store, feed, entry, user,
 			sanitizer.WithRewriteURL(
 				mediaproxy.New(h.router).WithAbsoluteProxy().RewriteURL))
 		if err != nil {
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/events"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/logging"
+	"miniflux.app/v2/internal/model"
+)
+
+// streamEvents streams changes of entries and feed counters of the user as
+// Server-Sent Events. The stream ends, when the client is too slow to receive
+// events, it should reconnect and sync entries with changed_after.
+func (h *handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	broker := h.store.Events()
+	if broker == nil {
+		response.NotFoundJSON(w, r)
+		return
+	}
+
+	ch, unsubscribe := broker.Subscribe(request.UserID(r))
+	defer unsubscribe()
+
+	stream, err := response.NewEventStream(w)
+	if err != nil {
+		response.ServerErrorJSON(w, r, err)
+		return
+	}
+
+	err = events.Stream(r.Context(), stream, ch, func(e *model.Event) error {
+		return stream.Send(e.Type, e)
+	})
+	if err != nil {
+		logging.FromContext(r.Context()).Debug("api: event stream closed",
+			slog.Any("error", err))
+	}
+}
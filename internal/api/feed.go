@@ -4,9 +4,12 @@
 package api // import "miniflux.app/v2/internal/api"
 
 import (
+	"context"
 	json_parser "encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"slices"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -16,6 +19,7 @@ import (
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/logging"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/validator"
 )
@@ -129,6 +133,129 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request,
 	return feed, nil
 }
 
+// updateFeeds applies the same changes to several feeds and returns them.
+func (h *handler) updateFeeds(w http.ResponseWriter, r *http.Request,
+) (model.Feeds, error) {
+	var modifyRequest model.FeedsModificationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&modifyRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	lerr := validator.ValidateFeedsModification(ctx, h.store, userID,
+		&modifyRequest)
+	if lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+
+	feeds, err := h.store.UpdateFeeds(ctx, userID, &modifyRequest)
+	if err != nil {
+		return nil, err
+	} else if feeds == nil {
+		return nil, response.ErrNotFound
+	}
+	return feeds, nil
+}
+
+// feedsByIDs returns feeds of the user with given IDs or
+// response.ErrNotFound, if one of them doesn't exist.
+func (h *handler) feedsByIDs(ctx context.Context, userID int64,
+	feedIDs []int64,
+) (model.Feeds, error) {
+	feeds, err := h.store.FeedsByIDs(ctx, userID, feedIDs)
+	if err != nil {
+		return nil, err
+	} else if len(feeds) != len(slices.Compact(slices.Sorted(
+		slices.Values(feedIDs)))) {
+		return nil, response.ErrNotFound
+	}
+	return feeds, nil
+}
+
+func (h *handler) previewFeedFilter(w http.ResponseWriter, r *http.Request,
+) (*model.FilterPreview, error) {
+	var previewRequest model.FilterPreviewRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&previewRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	if lerr := validator.ValidateFilterPreview(&previewRequest); lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+
+	ctx := r.Context()
+	user := request.User(r)
+	id := request.RouteInt64Param(r, "feedID")
+
+	feed, err := h.store.FeedByID(ctx, user.ID, id)
+	if err != nil {
+		return nil, err
+	} else if feed == nil {
+		return nil, response.ErrNotFound
+	}
+
+	previewRequest.Patch(feed)
+	return feedHandler.PreviewFilter(ctx, h.store, user, feed)
+}
+
+func (h *handler) applyFeedFilters(w http.ResponseWriter, r *http.Request,
+) (*applyFiltersResponse, error) {
+	ctx := r.Context()
+	user := request.User(r)
+	id := request.RouteInt64Param(r, "feedID")
+
+	feed, err := h.store.FeedByID(ctx, user.ID, id)
+	if err != nil {
+		return nil, err
+	} else if feed == nil {
+		return nil, response.ErrNotFound
+	}
+
+	changed, err := filter.FilterStoredEntries(ctx, h.store, user, feed)
+	if err != nil {
+		return nil, err
+	}
+	return &applyFiltersResponse{Changed: changed}, nil
+}
+
+func (h *handler) applyAllFilters(w http.ResponseWriter, r *http.Request,
+) (*applyFiltersResponse, error) {
+	ctx := r.Context()
+	user := request.User(r)
+
+	feeds, err := h.store.Feeds(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := filter.FilterStoredEntries(ctx, h.store, user, feeds...)
+	if err != nil {
+		return nil, err
+	}
+	return &applyFiltersResponse{Changed: changed}, nil
+}
+
+func (h *handler) getFilterRuleHits(w http.ResponseWriter, r *http.Request,
+) ([]model.FilterRuleHit, error) {
+	return h.store.FilterRuleHits(r.Context(), request.UserID(r))
+}
+
+func (h *handler) getFeedFilterRuleHits(w http.ResponseWriter,
+	r *http.Request,
+) ([]model.FilterRuleHit, error) {
+	ctx := r.Context()
+	userID := request.UserID(r)
+	id := request.RouteInt64Param(r, "feedID")
+
+	if exists, err := h.store.FeedExists(ctx, userID, id); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, response.ErrNotFound
+	}
+	return h.store.FeedFilterRuleHits(ctx, userID, id)
+}
+
 func (h *handler) markFeedAsRead(w http.ResponseWriter, r *http.Request) error {
 	id := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
@@ -198,6 +325,22 @@ func (h *handler) getFeed(w http.ResponseWriter, r *http.Request,
 	return feed, nil
 }
 
+func (h *handler) removeFeeds(w http.ResponseWriter, r *http.Request) error {
+	var removeRequest model.FeedsRemovalRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&removeRequest); err != nil {
+		return response.WrapBadRequest(err)
+	} else if len(removeRequest.FeedIDs) == 0 {
+		return response.WrapBadRequest(errors.New("empty list of feeds"))
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	if _, err := h.feedsByIDs(ctx, userID, removeRequest.FeedIDs); err != nil {
+		return err
+	}
+	return h.store.RemoveMultipleFeeds(ctx, userID, removeRequest.FeedIDs)
+}
+
 func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) error {
 	id := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
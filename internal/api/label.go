@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getLabels(w http.ResponseWriter, r *http.Request,
+) ([]model.Label, error) {
+	return h.store.Labels(r.Context(), request.UserID(r))
+}
+
+func (h *handler) createLabel(w http.ResponseWriter, r *http.Request,
+) (*model.Label, error) {
+	var labelRequest model.LabelRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&labelRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	lerr := validator.ValidateLabel(ctx, h.store, userID, 0, &labelRequest)
+	if lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+	return h.store.CreateLabel(ctx, userID, labelRequest.Title)
+}
+
+func (h *handler) updateLabel(w http.ResponseWriter, r *http.Request,
+) (*model.Label, error) {
+	ctx := r.Context()
+	userID := request.UserID(r)
+	id := request.RouteInt64Param(r, "labelID")
+
+	label, err := h.store.LabelByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	} else if label == nil {
+		return nil, response.ErrNotFound
+	}
+
+	var labelRequest model.LabelRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&labelRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	lerr := validator.ValidateLabel(ctx, h.store, userID, label.ID,
+		&labelRequest)
+	if lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+
+	label.Title = labelRequest.Title
+	affected, err := h.store.UpdateLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	} else if !affected {
+		return nil, response.ErrNotFound
+	}
+	return label, nil
+}
+
+func (h *handler) removeLabel(w http.ResponseWriter, r *http.Request) error {
+	id := request.RouteInt64Param(r, "labelID")
+	affected, err := h.store.RemoveLabel(r.Context(), request.UserID(r), id)
+	if err != nil {
+		return err
+	} else if !affected {
+		return response.ErrNotFound
+	}
+	return nil
+}
+
+func (h *handler) getEntryLabels(w http.ResponseWriter, r *http.Request,
+) ([]model.Label, error) {
+	ctx := r.Context()
+	userID := request.UserID(r)
+	id := request.RouteInt64Param(r, "entryID")
+
+	entry, err := h.store.NewEntryQueryBuilder(userID).
+		WithEntryID(id).
+		WithoutStatus(model.EntryStatusRemoved).
+		GetEntry(ctx)
+	if err != nil {
+		return nil, err
+	} else if entry == nil {
+		return nil, response.ErrNotFound
+	}
+
+	labels, err := h.store.EntryLabels(ctx, userID, []int64{id})
+	if err != nil {
+		return nil, err
+	} else if labels[id] == nil {
+		return []model.Label{}, nil
+	}
+	return labels[id], nil
+}
+
+func (h *handler) setEntryLabels(w http.ResponseWriter, r *http.Request,
+) error {
+	var labelsRequest model.EntryLabelsRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&labelsRequest); err != nil {
+		return response.WrapBadRequest(err)
+	}
+
+	id := request.RouteInt64Param(r, "entryID")
+	found, err := h.store.SetEntryLabels(r.Context(), request.UserID(r), id,
+		labelsRequest.LabelIDs)
+	if err != nil {
+		return err
+	} else if !found {
+		return response.ErrNotFound
+	}
+	return nil
+}
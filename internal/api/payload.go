@@ -37,10 +37,18 @@ type feedCreationResponse struct {
 	FeedID int64 `json:"feed_id,omitzero"`
 }
 
+type applyFiltersResponse struct {
+	Changed int `json:"changed"`
+}
+
 type importFeedsResponse struct {
 	Message string `json:"message,omitzero"`
 }
 
+type importSiteRulesResponse struct {
+	Imported int `json:"imported"`
+}
+
 type VersionResponse struct {
 	Version   string `json:"version"`
 	Commit    string `json:"commit"`
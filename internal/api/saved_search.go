@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getSavedSearches(w http.ResponseWriter, r *http.Request,
+) ([]model.SavedSearch, error) {
+	user := request.User(r)
+	return h.store.SavedSearchesWithCounters(r.Context(), user.ID,
+		timezone.Location(user.Timezone))
+}
+
+func (h *handler) createSavedSearch(w http.ResponseWriter, r *http.Request,
+) (*model.SavedSearch, error) {
+	var searchRequest model.SavedSearchRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&searchRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	lerr := validator.ValidateSavedSearch(ctx, h.store, userID, 0,
+		&searchRequest)
+	if lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+	return h.store.CreateSavedSearch(ctx, userID, &searchRequest)
+}
+
+func (h *handler) updateSavedSearch(w http.ResponseWriter, r *http.Request,
+) (*model.SavedSearch, error) {
+	ctx := r.Context()
+	userID := request.UserID(r)
+	id := request.RouteInt64Param(r, "savedSearchID")
+
+	search, err := h.store.SavedSearchByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	} else if search == nil {
+		return nil, response.ErrNotFound
+	}
+
+	searchRequest := model.SavedSearchRequest{
+		Title: search.Title,
+		Query: search.Query,
+	}
+	if err := json_parser.NewDecoder(r.Body).Decode(&searchRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	lerr := validator.ValidateSavedSearch(ctx, h.store, userID, search.ID,
+		&searchRequest)
+	if lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+
+	search.Title = searchRequest.Title
+	search.Query = searchRequest.Query
+	affected, err := h.store.UpdateSavedSearch(ctx, search)
+	if err != nil {
+		return nil, err
+	} else if !affected {
+		return nil, response.ErrNotFound
+	}
+	return search, nil
+}
+
+func (h *handler) removeSavedSearch(w http.ResponseWriter, r *http.Request,
+) error {
+	id := request.RouteInt64Param(r, "savedSearchID")
+	affected, err := h.store.RemoveSavedSearch(r.Context(), request.UserID(r),
+		id)
+	if err != nil {
+		return err
+	} else if !affected {
+		return response.ErrNotFound
+	}
+	return nil
+}
+
+func (h *handler) getSavedSearchEntries(w http.ResponseWriter, r *http.Request,
+) (*entriesResponse, error) {
+	id := request.RouteInt64Param(r, "savedSearchID")
+	search, err := h.store.SavedSearchByID(r.Context(), request.UserID(r), id)
+	if err != nil {
+		return nil, err
+	} else if search == nil {
+		return nil, response.ErrNotFound
+	}
+	return h.entriesFinder().WithSearch(search.Query).Entries(r)
+}
+
+func (h *handler) markSavedSearchAsRead(w http.ResponseWriter,
+	r *http.Request,
+) error {
+	ctx := r.Context()
+	user := request.User(r)
+	id := request.RouteInt64Param(r, "savedSearchID")
+
+	search, err := h.store.SavedSearchByID(ctx, user.ID, id)
+	if err != nil {
+		return err
+	} else if search == nil {
+		return response.ErrNotFound
+	}
+
+	q, err := search.SearchQuery(timezone.Location(user.Timezone))
+	if err != nil {
+		return response.WrapBadRequest(err)
+	}
+	return h.store.MarkSavedSearchAsRead(ctx, user.ID, q, time.Now())
+}
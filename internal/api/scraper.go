@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/urllib"
+)
+
+func (h *handler) entryScraperDiagnostics(w http.ResponseWriter,
+	r *http.Request,
+) (*model.ScraperDiagnostics, error) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+	ctx := r.Context()
+
+	entry, err := h.store.NewEntryQueryBuilder(userID).
+		WithEntryID(entryID).
+		WithoutStatus(model.EntryStatusRemoved).
+		GetEntry(ctx)
+	if err != nil {
+		return nil, err
+	} else if entry == nil {
+		return nil, response.ErrNotFound
+	}
+
+	feed, err := h.store.FeedByID(ctx, userID, entry.FeedID)
+	if err != nil {
+		return nil, err
+	} else if feed == nil {
+		return nil, response.ErrNotFound
+	}
+	return processor.DiagnoseScraper(ctx, h.store, feed, entry,
+		request.User(r))
+}
+
+func (h *handler) scraperDiagnostics(w http.ResponseWriter, r *http.Request,
+) (*model.ScraperDiagnostics, error) {
+	pageURL := request.QueryStringParam(r, "url", "")
+	if pageURL == "" {
+		return nil, response.WrapBadRequest(errors.New("missing url parameter"))
+	} else if !urllib.IsAbsoluteURL(pageURL) {
+		return nil, response.WrapBadRequest(errors.New("invalid url parameter"))
+	}
+
+	return processor.DiagnoseScraper(r.Context(), h.store, &model.Feed{},
+		&model.Entry{URL: pageURL}, request.User(r))
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"bytes"
+	json_parser "encoding/json"
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/siterules"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getSiteRules(w http.ResponseWriter, r *http.Request,
+) (model.SiteRules, error) {
+	return h.store.SiteRules(r.Context(), request.UserID(r))
+}
+
+func (h *handler) createSiteRule(w http.ResponseWriter, r *http.Request,
+) (*model.SiteRule, error) {
+	var ruleRequest model.SiteRuleRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	} else if ruleRequest.Global && !request.IsAdminUser(r) {
+		return nil, response.ErrForbidden
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	lerr := validator.ValidateSiteRule(ctx, h.store,
+		ruleRequest.UserID(userID), 0, &ruleRequest)
+	if lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+	return h.store.CreateSiteRule(ctx, userID, &ruleRequest)
+}
+
+// siteRule returns the site rule from the route, which the user can modify:
+// own site rules or global site rules for admins.
+func (h *handler) siteRule(r *http.Request) (*model.SiteRule, error) {
+	id := request.RouteInt64Param(r, "siteRuleID")
+	rule, err := h.store.SiteRuleByID(r.Context(), request.UserID(r), id)
+	if err != nil {
+		return nil, err
+	} else if rule == nil {
+		return nil, response.ErrNotFound
+	} else if rule.Global() && !request.IsAdminUser(r) {
+		return nil, response.ErrForbidden
+	}
+	return rule, nil
+}
+
+func (h *handler) updateSiteRule(w http.ResponseWriter, r *http.Request,
+) (*model.SiteRule, error) {
+	rule, err := h.siteRule(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleRequest := rule.Request()
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleRequest); err != nil {
+		return nil, response.WrapBadRequest(err)
+	}
+
+	ctx := r.Context()
+	lerr := validator.ValidateSiteRule(ctx, h.store, rule.UserID, rule.ID,
+		&ruleRequest)
+	if lerr != nil {
+		return nil, response.WrapBadRequest(lerr.Error())
+	}
+
+	rule.Hostname = ruleRequest.Hostname
+	rule.ScraperRules = ruleRequest.ScraperRules
+	rule.RewriteRules = ruleRequest.RewriteRules
+	rule.Referer = ruleRequest.Referer
+	rule.UserAgent = ruleRequest.UserAgent
+	affected, err := h.store.UpdateSiteRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	} else if !affected {
+		return nil, response.ErrNotFound
+	}
+	return rule, nil
+}
+
+func (h *handler) removeSiteRule(w http.ResponseWriter, r *http.Request,
+) error {
+	rule, err := h.siteRule(r)
+	if err != nil {
+		return err
+	}
+
+	affected, err := h.store.RemoveSiteRule(r.Context(), rule.UserID, rule.ID)
+	if err != nil {
+		return err
+	} else if !affected {
+		return response.ErrNotFound
+	}
+	return nil
+}
+
+// siteRulesOwner returns the owner of site rules for export and import: the
+// user or nobody, when global site rules are requested.
+func siteRulesOwner(r *http.Request) int64 {
+	if request.QueryBoolParam(r, "global", false) {
+		return 0
+	}
+	return request.UserID(r)
+}
+
+func (h *handler) exportSiteRules(w http.ResponseWriter, r *http.Request) {
+	var b bytes.Buffer
+	err := siterules.NewHandler(h.store).Export(r.Context(), siteRulesOwner(r),
+		&b)
+	if err != nil {
+		response.ServerErrorJSON(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", "application/yaml").
+		WithHeader("Content-Disposition",
+			`attachment; filename="miniflux-site-rules.yaml"`).
+		WithBodyAsBytes(b.Bytes()).
+		Write()
+}
+
+func (h *handler) importSiteRules(w http.ResponseWriter, r *http.Request,
+) (*importSiteRulesResponse, error) {
+	userID := siteRulesOwner(r)
+	if userID == 0 && !request.IsAdminUser(r) {
+		return nil, response.ErrForbidden
+	}
+
+	imported, err := siterules.NewHandler(h.store).Import(r.Context(), userID,
+		r.Body)
+	if errors.Is(err, siterules.ErrInvalidDocument) {
+		return nil, response.WrapBadRequest(err)
+	} else if err != nil {
+		return nil, err
+	}
+	return &importSiteRulesResponse{Imported: imported}, nil
+}
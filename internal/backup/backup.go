@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package backup exports and restores full user accounts.
+package backup // import "miniflux.app/v2/internal/backup"
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// archiveName is the name of the account archive in the zip file.
+const archiveName = "account.json"
+
+// MaxArchiveSize is the maximum size of an account archive, compressed or
+// not, which can be restored.
+const MaxArchiveSize = 512 << 20
+
+// ErrInvalidArchive is returned for archives, which can't be restored.
+var ErrInvalidArchive = errors.New("backup: invalid account archive")
+
+// NewArchive returns an empty account archive of the current version.
+func NewArchive() *model.AccountArchive {
+	return &model.AccountArchive{
+		Version:    model.AccountArchiveVersion,
+		ExportedAt: time.Now().UTC(),
+	}
+}
+
+// Encode writes the account archive as a zip file.
+func Encode(w io.Writer, archive *model.AccountArchive) error {
+	zw := zip.NewWriter(w)
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     archiveName,
+		Method:   zip.Deflate,
+		Modified: archive.ExportedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("backup: create %s: %w", archiveName, err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(archive); err != nil {
+		return fmt.Errorf("backup: encode account archive: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("backup: close zip file: %w", err)
+	}
+	return nil
+}
+
+// Decode reads an account archive from a zip file, created by Encode, or from
+// its JSON content.
+func Decode(b []byte) (*model.AccountArchive, error) {
+	if bytes.HasPrefix(b, []byte("PK\x03\x04")) {
+		content, err := unzip(b)
+		if err != nil {
+			return nil, err
+		}
+		b = content
+	}
+
+	var archive model.AccountArchive
+	if err := json.Unmarshal(b, &archive); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	switch {
+	case archive.Version == 0:
+		return nil, fmt.Errorf("%w: missing version", ErrInvalidArchive)
+	case archive.Version > model.AccountArchiveVersion:
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive,
+			archive.Version)
+	}
+	return &archive, nil
+}
+
+func unzip(b []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	f, err := zr.Open(archiveName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	defer f.Close()
+
+	content, err := readAll(f, archiveName)
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// readAll reads r until EOF and returns ErrInvalidArchive if it has more than
+// MaxArchiveSize bytes.
+func readAll(r io.Reader, name string) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, MaxArchiveSize+1))
+	if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidArchive, name,
+			MaxArchiveSize)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: read %s: %w", ErrInvalidArchive, name, err)
+	} else if len(b) > MaxArchiveSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidArchive, name,
+			MaxArchiveSize)
+	}
+	return b, nil
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup // import "miniflux.app/v2/internal/backup"
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	archive := NewArchive()
+	archive.Feeds = []*model.Feed{{
+		FeedURL:  "https://example.org/feed.xml",
+		Category: &model.Category{Title: "News"},
+	}}
+	archive.Entries = []*model.ArchivedEntry{{
+		Entry: &model.Entry{
+			Hash:    "hash",
+			Status:  model.EntryStatusUnread,
+			Starred: true,
+			Extra: model.EntryExtra{
+				Enclosures: model.EnclosureList{
+					{URL: "https://example.org/a.mp3", MediaProgression: 42},
+				},
+			},
+		},
+		FeedURL: "https://example.org/feed.xml",
+		Labels:  []string{"Later"},
+	}}
+
+	var b bytes.Buffer
+	require.NoError(t, Encode(&b, archive))
+
+	decoded, err := Decode(b.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, model.AccountArchiveVersion, decoded.Version)
+	require.Len(t, decoded.Feeds, 1)
+	assert.Equal(t, "News", decoded.Feeds[0].Category.Title)
+
+	require.Len(t, decoded.Entries, 1)
+	entry := decoded.Entries[0]
+	assert.Equal(t, "hash", entry.Hash)
+	assert.True(t, entry.Starred)
+	assert.Equal(t, "https://example.org/feed.xml", entry.FeedURL)
+	assert.Equal(t, []string{"Later"}, entry.Labels)
+	require.Len(t, entry.Enclosures(), 1)
+	assert.Equal(t, int64(42), entry.Enclosures()[0].MediaProgression)
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "json", input: `{"version": 1, "entries": []}`},
+		{name: "no version", input: `{"entries": []}`, wantErr: true},
+		{name: "newer version", input: `{"version": 99}`, wantErr: true},
+		{name: "invalid json", input: `{`, wantErr: true},
+		{name: "invalid zip", input: "PK\x03\x04", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, err := Decode([]byte(tt.input))
+			if tt.wantErr {
+				t.Log(err)
+				require.ErrorIs(t, err, ErrInvalidArchive)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, archive.Version)
+		})
+	}
+}
+
+func TestReadAll_maxBytes(t *testing.T) {
+	r := http.MaxBytesReader(nil, io.NopCloser(strings.NewReader("{}")), 1)
+	_, err := readAll(r, archiveName)
+	t.Log(err)
+	require.ErrorIs(t, err, ErrInvalidArchive)
+}
@@ -0,0 +1,627 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package backup // import "miniflux.app/v2/internal/backup"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+// Handler handles the logic for account export and restore.
+type Handler struct {
+	store *storage.Storage
+}
+
+// NewHandler creates a new handler for account archives.
+func NewHandler(store *storage.Storage) *Handler {
+	return &Handler{store: store}
+}
+
+// Export writes the account archive of the user as a zip file.
+func (h *Handler) Export(ctx context.Context, userID int64, w io.Writer,
+) error {
+	archive, err := h.archive(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return Encode(w, archive)
+}
+
+func (h *Handler) archive(ctx context.Context, userID int64,
+) (*model.AccountArchive, error) {
+	user, err := h.store.UserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, fmt.Errorf("backup: user #%d not found", userID)
+	}
+
+	archive := NewArchive()
+	archive.Settings = user
+
+	categories, err := h.store.Categories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range categories {
+		archive.Categories = append(archive.Categories, &categories[i])
+	}
+
+	feeds, err := h.store.Feeds(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, feed := range feeds {
+		feed.Icon, feed.Runtime = nil, model.FeedRuntime{}
+	}
+	archive.Feeds = feeds
+
+	labels, err := h.store.Labels(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range labels {
+		archive.Labels = append(archive.Labels, label.Title)
+	}
+
+	searches, err := h.store.SavedSearches(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, search := range searches {
+		archive.SavedSearches = append(archive.SavedSearches,
+			model.SavedSearchRequest{Title: search.Title, Query: search.Query})
+	}
+
+	apiKeys, err := h.store.APIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range apiKeys {
+		archive.APIKeys = append(archive.APIKeys, key.Description)
+	}
+
+	entries, err := h.entries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	archive.Entries = entries
+	return archive, nil
+}
+
+// entries returns unread, starred and labeled entries of the user.
+func (h *Handler) entries(ctx context.Context, userID int64,
+) ([]*model.ArchivedEntry, error) {
+	unread, err := h.store.NewEntryQueryBuilder(userID).
+		WithStatus(model.EntryStatusUnread).
+		WithContent(true).
+		WithSorting("id", "asc").
+		GetEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kept, err := h.store.NewEntryQueryBuilder(userID).
+		WithStatus(model.EntryStatusRead).
+		WithStarredOrLabeled().
+		WithContent(true).
+		WithSorting("id", "asc").
+		GetEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := slices.Concat(unread, kept)
+	entryIDs := make([]int64, len(entries))
+	for i, e := range entries {
+		entryIDs[i] = e.ID
+	}
+
+	labels, err := h.store.EntryLabels(ctx, userID, entryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := make([]*model.ArchivedEntry, len(entries))
+	for i, e := range entries {
+		archived[i] = &model.ArchivedEntry{Entry: e, FeedURL: e.Feed.FeedURL}
+		for _, label := range labels[e.ID] {
+			archived[i].Labels = append(archived[i].Labels, label.Title)
+		}
+		e.Feed = nil
+	}
+	return archived, nil
+}
+
+// Import restores the account archive, created by Export, for the user.
+// Existing categories, feeds, labels and saved searches are kept, entries are
+// updated.
+func (h *Handler) Import(ctx context.Context, userID int64, r io.Reader,
+) (*model.AccountRestoreResult, error) {
+	b, err := readAll(r, "account archive")
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := Decode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.store.UserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, fmt.Errorf("backup: user #%d not found", userID)
+	}
+
+	restore := restore{
+		store:      h.store,
+		user:       user,
+		archive:    archive,
+		categories: make(map[string]*model.Category),
+		feeds:      make(map[string]int64),
+		labels:     make(map[string]int64),
+	}
+	return restore.run(ctx)
+}
+
+type restore struct {
+	store   *storage.Storage
+	user    *model.User
+	archive *model.AccountArchive
+	result  model.AccountRestoreResult
+
+	settings   *model.UserModificationRequest
+	categories map[string]*model.Category
+	feeds      map[string]int64
+	labels     map[string]int64
+}
+
+func (self *restore) run(ctx context.Context,
+) (*model.AccountRestoreResult, error) {
+	// Every step writes on its own, so the whole archive is validated first,
+	// to not restore an invalid archive partially.
+	if err := self.validate(ctx); err != nil {
+		return nil, err
+	}
+
+	steps := [...]func(context.Context) error{
+		self.restoreSettings,
+		self.restoreCategories,
+		self.restoreFeeds,
+		self.restoreLabels,
+		self.restoreSavedSearches,
+		self.restoreAPIKeys,
+		self.restoreEntries,
+	}
+
+	for _, fn := range steps {
+		if err := fn(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &self.result, nil
+}
+
+// validate returns ErrInvalidArchive, if anything of the archive can't be
+// restored. It doesn't change anything.
+func (self *restore) validate(ctx context.Context) error {
+	steps := [...]func(context.Context) error{
+		self.validateSettings,
+		self.validateCategories,
+		self.validateFeeds,
+		self.validateLabels,
+		self.validateSavedSearches,
+		self.validateEntries,
+	}
+
+	for _, fn := range steps {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (self *restore) validateSettings(ctx context.Context) error {
+	settings := self.archive.Settings
+	if settings == nil {
+		return nil
+	}
+
+	m := &model.UserModificationRequest{
+		Theme:                           &settings.Theme,
+		Language:                        &settings.Language,
+		Timezone:                        &settings.Timezone,
+		EntryDirection:                  &settings.EntryDirection,
+		EntryOrder:                      &settings.EntryOrder,
+		Stylesheet:                      &settings.Stylesheet,
+		CustomJS:                        &settings.CustomJS,
+		ExternalFontHosts:               &settings.ExternalFontHosts,
+		EntriesPerPage:                  &settings.EntriesPerPage,
+		KeyboardShortcuts:               &settings.KeyboardShortcuts,
+		ShowReadingTime:                 &settings.ShowReadingTime,
+		EntrySwipe:                      &settings.EntrySwipe,
+		GestureNav:                      &settings.GestureNav,
+		DisplayMode:                     &settings.DisplayMode,
+		DefaultReadingSpeed:             &settings.DefaultReadingSpeed,
+		CJKReadingSpeed:                 &settings.CJKReadingSpeed,
+		DefaultHomePage:                 &settings.DefaultHomePage,
+		CategoriesSortingOrder:          &settings.CategoriesSortingOrder,
+		MarkReadOnView:                  &settings.MarkReadOnView,
+		MarkReadOnMediaPlayerCompletion: &settings.MarkReadOnMediaPlayerCompletion,
+		MediaPlaybackRate:               &settings.MediaPlaybackRate,
+		BlockFilterEntryRules:           &settings.BlockFilterEntryRules,
+		KeepFilterEntryRules:            &settings.KeepFilterEntryRules,
+		AlwaysOpenExternalLinks:         &settings.Extra.AlwaysOpenExternalLinks,
+		OpenExternalLinkSameTab:         &settings.Extra.OpenExternalLinkSameTab,
+		TagEntryRules:                   &settings.Extra.TagEntryRules,
+	}
+
+	lerr := validator.ValidateUserModification(ctx, self.store, self.user.ID, m)
+	if lerr != nil {
+		return fmt.Errorf("%w: settings: %w", ErrInvalidArchive, lerr.Error())
+	}
+	self.settings = m
+	return nil
+}
+
+func (self *restore) validateCategories(ctx context.Context) error {
+	for _, archived := range self.archive.Categories {
+		if archived == nil {
+			return fmt.Errorf("%w: empty category", ErrInvalidArchive)
+		}
+
+		category, err := self.store.CategoryByTitle(ctx, self.user.ID,
+			archived.Title)
+		if err != nil {
+			return err
+		} else if category != nil {
+			continue
+		}
+
+		r := model.CategoryCreationRequest{Title: archived.Title}
+		lerr := validator.ValidateCategoryCreation(ctx, self.store, self.user.ID,
+			&r)
+		if lerr != nil {
+			return fmt.Errorf("%w: category %q: %w", ErrInvalidArchive,
+				archived.Title, lerr.Error())
+		}
+	}
+	return nil
+}
+
+func (self *restore) validateFeeds(ctx context.Context) error {
+	for _, archived := range self.archive.Feeds {
+		if archived == nil {
+			return fmt.Errorf("%w: empty feed", ErrInvalidArchive)
+		} else if archived.FeedURL == "" {
+			return fmt.Errorf("%w: feed %q without URL", ErrInvalidArchive,
+				archived.Title)
+		}
+
+		feed, err := self.store.FeedByURL(ctx, self.user.ID, archived.FeedURL)
+		if err != nil {
+			return err
+		} else if feed != nil {
+			continue
+		}
+
+		// Categories of the archive are restored before feeds, so the category
+		// isn't validated here.
+		r := model.FeedModificationRequest{
+			FeedURL:               &archived.FeedURL,
+			ScraperRules:          &archived.ScraperRules,
+			UrlRewriteRules:       &archived.UrlRewriteRules,
+			BlockFilterEntryRules: &archived.Extra.BlockFilterEntryRules,
+			KeepFilterEntryRules:  &archived.Extra.KeepFilterEntryRules,
+			ProxyURL:              &archived.ProxyURL,
+			CommentsURLTemplate:   &archived.Extra.CommentsURLTemplate,
+			RefreshInterval:       &archived.Extra.RefreshInterval,
+			Retention:             &archived.Extra.Retention,
+		}
+		if archived.SiteURL != "" {
+			r.SiteURL = &archived.SiteURL
+		}
+
+		lerr := validator.ValidateFeedModification(ctx, self.store, self.user.ID,
+			0, &r)
+		if lerr != nil {
+			return fmt.Errorf("%w: feed %q: %w", ErrInvalidArchive,
+				archived.FeedURL, lerr.Error())
+		}
+	}
+	return nil
+}
+
+func (self *restore) validateLabels(ctx context.Context) error {
+	for _, title := range self.labelTitles() {
+		label, err := self.store.LabelByTitle(ctx, self.user.ID, title)
+		if err != nil {
+			return err
+		} else if label != nil {
+			continue
+		}
+
+		// Categories of the archive are restored before labels.
+		lerr := validator.ValidateLabel(ctx, self.store, self.user.ID, 0,
+			&model.LabelRequest{Title: title})
+		if lerr == nil && slices.ContainsFunc(self.archive.Categories,
+			func(c *model.Category) bool { return c.Title == title }) {
+			lerr = locale.NewLocalizedError(
+				"A category with the same title already exists.")
+		}
+		if lerr != nil {
+			return fmt.Errorf("%w: label %q: %w", ErrInvalidArchive, title,
+				lerr.Error())
+		}
+	}
+	return nil
+}
+
+// labelTitles returns titles of labels and labels of entries of the archive.
+func (self *restore) labelTitles() []string {
+	titles := slices.Clone(self.archive.Labels)
+	for _, e := range self.archive.Entries {
+		if e != nil {
+			titles = append(titles, e.Labels...)
+		}
+	}
+	slices.Sort(titles)
+	return slices.Compact(titles)
+}
+
+func (self *restore) validateSavedSearches(ctx context.Context) error {
+	for i := range self.archive.SavedSearches {
+		r := &self.archive.SavedSearches[i]
+		search, err := self.store.SavedSearchByTitle(ctx, self.user.ID,
+			strings.TrimSpace(r.Title))
+		if err != nil {
+			return err
+		} else if search != nil {
+			continue
+		}
+
+		lerr := validator.ValidateSavedSearch(ctx, self.store, self.user.ID, 0, r)
+		if lerr != nil {
+			return fmt.Errorf("%w: saved search %q: %w", ErrInvalidArchive,
+				r.Title, lerr.Error())
+		}
+	}
+	return nil
+}
+
+func (self *restore) validateEntries(context.Context) error {
+	feedURLs := make(map[string]struct{}, len(self.archive.Feeds))
+	for _, f := range self.archive.Feeds {
+		feedURLs[f.FeedURL] = struct{}{}
+	}
+
+	for _, archived := range self.archive.Entries {
+		if archived == nil || archived.Entry == nil || archived.Hash == "" {
+			return fmt.Errorf("%w: entry without hash", ErrInvalidArchive)
+		}
+
+		if _, ok := feedURLs[archived.FeedURL]; !ok {
+			return fmt.Errorf("%w: entry %q of unknown feed %q",
+				ErrInvalidArchive, archived.Hash, archived.FeedURL)
+		}
+
+		if err := validator.ValidateEntryStatus(archived.Status); err != nil {
+			return fmt.Errorf("%w: entry %q: %w", ErrInvalidArchive,
+				archived.Hash, err)
+		}
+	}
+	return nil
+}
+
+func (self *restore) restoreSettings(ctx context.Context) error {
+	if self.settings == nil {
+		return nil
+	}
+
+	self.settings.Patch(self.user)
+	self.user.Extra.Integration = self.archive.Settings.Extra.Integration
+	return self.store.UpdateUser(ctx, self.user)
+}
+
+func (self *restore) restoreCategories(ctx context.Context) error {
+	for _, archived := range self.archive.Categories {
+		category, err := self.store.CategoryByTitle(ctx, self.user.ID,
+			archived.Title)
+		if err != nil {
+			return err
+		} else if category != nil {
+			self.categories[archived.Title] = category
+			continue
+		}
+
+		r := model.CategoryCreationRequest{
+			Title:        archived.Title,
+			HideGlobally: archived.HideGlobally,
+		}
+		category, err = self.store.CreateCategory(ctx, self.user.ID, &r)
+		if err != nil {
+			return err
+		}
+
+		category.Extra = archived.Extra
+		if _, err := self.store.UpdateCategory(ctx, category); err != nil {
+			return err
+		}
+		self.categories[archived.Title] = category
+		self.result.Categories++
+	}
+	return nil
+}
+
+func (self *restore) restoreFeeds(ctx context.Context) error {
+	for _, archived := range self.archive.Feeds {
+		feed, err := self.store.FeedByURL(ctx, self.user.ID, archived.FeedURL)
+		if err != nil {
+			return err
+		} else if feed != nil {
+			self.feeds[feed.FeedURL] = feed.ID
+			continue
+		}
+
+		category, err := self.category(ctx, archived.Category)
+		if err != nil {
+			return err
+		}
+
+		feed = &model.Feed{}
+		*feed = *archived
+		feed.ID, feed.UserID, feed.Category = 0, self.user.ID, category
+		feed.EtagHeader, feed.LastModifiedHeader = "", ""
+		feed.ParsingErrorMsg, feed.ParsingErrorCount = "", 0
+		feed.Entries = nil
+
+		if err := self.store.CreateFeed(ctx, feed); err != nil {
+			return err
+		}
+		// CreateFeed doesn't store all settings of notifications.
+		if err := self.store.UpdateFeed(ctx, feed); err != nil {
+			return err
+		}
+		self.feeds[feed.FeedURL] = feed.ID
+		self.result.Feeds++
+	}
+	return nil
+}
+
+// category returns the restored category with the title of the given one or
+// the first category of the user.
+func (self *restore) category(ctx context.Context, c *model.Category,
+) (*model.Category, error) {
+	if c != nil {
+		if category, ok := self.categories[c.Title]; ok {
+			return category, nil
+		}
+	}
+
+	category, err := self.store.FirstCategory(ctx, self.user.ID)
+	if err != nil {
+		return nil, err
+	} else if category == nil {
+		return nil, errors.New("backup: user without categories")
+	}
+	return category, nil
+}
+
+func (self *restore) restoreLabels(ctx context.Context) error {
+	for _, title := range self.labelTitles() {
+		label, err := self.store.LabelByTitle(ctx, self.user.ID, title)
+		if err != nil {
+			return err
+		} else if label == nil {
+			label, err = self.store.CreateLabel(ctx, self.user.ID, title)
+			if err != nil {
+				return err
+			}
+			self.result.Labels++
+		}
+		self.labels[title] = label.ID
+	}
+	return nil
+}
+
+func (self *restore) restoreSavedSearches(ctx context.Context) error {
+	for _, r := range self.archive.SavedSearches {
+		search, err := self.store.SavedSearchByTitle(ctx, self.user.ID, r.Title)
+		if err != nil {
+			return err
+		} else if search != nil {
+			continue
+		}
+
+		_, err = self.store.CreateSavedSearch(ctx, self.user.ID, &r)
+		if err != nil {
+			return err
+		}
+		self.result.SavedSearches++
+	}
+	return nil
+}
+
+func (self *restore) restoreAPIKeys(ctx context.Context) error {
+	keys, err := self.store.APIKeys(ctx, self.user.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, description := range self.archive.APIKeys {
+		exists := slices.ContainsFunc(keys, func(key model.APIKey) bool {
+			return key.Description == description
+		})
+		if exists {
+			continue
+		}
+
+		key, err := self.store.CreateAPIKey(ctx, self.user.ID, description)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, *key)
+		self.result.APIKeys++
+	}
+	return nil
+}
+
+func (self *restore) restoreEntries(ctx context.Context) error {
+	byFeed := make(map[int64]model.Entries)
+	var feedIDs []int64
+	for _, archived := range self.archive.Entries {
+		feedID, ok := self.feeds[archived.FeedURL]
+		if !ok {
+			return fmt.Errorf("%w: entry %q of unknown feed %q",
+				ErrInvalidArchive, archived.Hash, archived.FeedURL)
+		}
+
+		e := archived.Entry
+		e.ID, e.Feed = 0, nil
+		e.WithImportedState(e.Status, e.Starred)
+		if _, ok := byFeed[feedID]; !ok {
+			feedIDs = append(feedIDs, feedID)
+		}
+		byFeed[feedID] = append(byFeed[feedID], e)
+	}
+
+	for _, feedID := range feedIDs {
+		_, err := self.store.StoreFeedEntries(ctx, self.user.ID, feedID,
+			byFeed[feedID], true)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, archived := range self.archive.Entries {
+		if len(archived.Labels) == 0 {
+			continue
+		}
+
+		labelIDs := make([]int64, len(archived.Labels))
+		for i, title := range archived.Labels {
+			labelIDs[i] = self.labels[title]
+		}
+
+		_, err := self.store.SetEntryLabels(ctx, self.user.ID, archived.ID,
+			labelIDs)
+		if err != nil {
+			return err
+		}
+	}
+	self.result.Entries = len(self.archive.Entries)
+	return nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"miniflux.app/v2/internal/backup"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+var exportUserCmd = cobra.Command{
+	Use:   "export-user username [file.zip]",
+	Short: "Export the full user account to a file or to stdout",
+
+	Args: cobra.RangeArgs(1, 2),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withStorage(
+			func(ctx context.Context, store *storage.Storage) error {
+				if len(args) == 1 {
+					return exportUser(ctx, store, args[0], os.Stdout)
+				}
+
+				f, err := os.Create(args[1])
+				if err != nil {
+					return err
+				}
+				if err := exportUser(ctx, store, args[0], f); err != nil {
+					f.Close()
+					return err
+				}
+				return f.Close()
+			})
+	},
+}
+
+var importUserCmd = cobra.Command{
+	Use:   "import-user username file.zip",
+	Short: "Restore the full user account from a file of export-user",
+
+	Args: cobra.ExactArgs(2),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withStorage(
+			func(ctx context.Context, store *storage.Storage) error {
+				return importUser(ctx, store, args[0], args[1])
+			})
+	},
+}
+
+func exportUser(ctx context.Context, store *storage.Storage, username string,
+	w io.Writer,
+) error {
+	user, err := userByUsername(ctx, store, username)
+	if err != nil {
+		return err
+	}
+
+	if err := backup.NewHandler(store).Export(ctx, user.ID, w); err != nil {
+		return fmt.Errorf("unable to export user: %w", err)
+	}
+	return nil
+}
+
+func importUser(ctx context.Context, store *storage.Storage, username,
+	filename string,
+) error {
+	user, err := userByUsername(ctx, store, username)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := backup.NewHandler(store).Import(ctx, user.ID, f)
+	if err != nil {
+		return fmt.Errorf("unable to import user: %w", err)
+	}
+	printRestoreResult(result)
+	return nil
+}
+
+func userByUsername(ctx context.Context, store *storage.Storage,
+	username string,
+) (*model.User, error) {
+	user, err := store.UserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find user: %w", err)
+	} else if user == nil {
+		return nil, fmt.Errorf("user %q not found", username)
+	}
+	return user, nil
+}
+
+func printRestoreResult(r *model.AccountRestoreResult) {
+	fmt.Printf("Restored %d categories, %d feeds, %d labels, %d saved searches, "+
+		"%d API keys and %d entries\n", r.Categories, r.Feeds, r.Labels,
+		r.SavedSearches, r.APIKeys, r.Entries)
+}
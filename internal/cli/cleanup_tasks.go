@@ -19,7 +19,7 @@ import (
 
 var cleanupTasksCmd = cobra.Command{
 	Use:   "run-cleanup-tasks",
-	Short: "Run cleanup tasks (delete old sessions and archive old entries)",
+	Short: "Run cleanup tasks (delete old sessions, archive and compact old entries)",
 	Args:  cobra.ExactArgs(0),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -81,4 +81,42 @@ func runCleanupTasks(ctx context.Context, store *storage.Storage) {
 				Observe(time.Since(startTime).Seconds())
 		}
 	}
+
+	startTime = time.Now()
+	rows, err = store.ArchiveEntriesByRetention(ctx,
+		config.CleanupArchiveBatchSize())
+	if err != nil {
+		log.Error("Unable to archive entries by retention policies",
+			slog.Any("error", err))
+	} else {
+		log.Info("Archiving entries by retention policies completed",
+			slog.Int64("entries_archived", rows),
+			slog.Duration("elapsed", time.Since(startTime)))
+
+		if config.HasMetricsCollector() {
+			metric.ArchiveEntriesDuration.
+				WithLabelValues("retention").
+				Observe(time.Since(startTime).Seconds())
+		}
+	}
+
+	if config.CleanupCompactRemovedDays() < 0 {
+		return
+	}
+
+	startTime = time.Now()
+	rows, err = store.CompactRemovedEntries(ctx,
+		config.CleanupCompactRemovedDays(),
+		config.CleanupArchiveBatchSize())
+	if err != nil {
+		log.Error("Unable to compact removed entries", slog.Any("error", err))
+	} else {
+		log.Info("Compacting removed entries completed",
+			slog.Int64("removed_entries_compacted", rows),
+			slog.Duration("elapsed", time.Since(startTime)))
+
+		if config.HasMetricsCollector() {
+			metric.ReclaimedEntries.WithLabelValues("compact").Add(float64(rows))
+		}
+	}
 }
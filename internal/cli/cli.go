@@ -106,9 +106,12 @@ func init() {
 	Cmd.AddCommand(&cleanupTasksCmd)
 	Cmd.AddCommand(&configDumpCmd)
 	Cmd.AddCommand(&createAdminCmd)
+	Cmd.AddCommand(&exportUserCmd)
 	Cmd.AddCommand(&exportUserFeedsCmd)
+	Cmd.AddCommand(&filterEntriesCmd)
 	Cmd.AddCommand(&flushSessionsCmd)
 	Cmd.AddCommand(&healthCmd)
+	Cmd.AddCommand(&importUserCmd)
 	Cmd.AddCommand(&infoCmd)
 	Cmd.AddCommand(&migrateCmd)
 	Cmd.AddCommand(&refreshFeedsCmd)
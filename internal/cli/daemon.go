@@ -15,6 +15,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/events"
 	"miniflux.app/v2/internal/http/mux"
 	"miniflux.app/v2/internal/http/server"
 	"miniflux.app/v2/internal/metric"
@@ -65,7 +66,7 @@ func (self *Daemon) configure(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	self.store = store
+	self.store = store.WithEvents(events.NewBroker())
 
 	// Run migrations and start the daemon.
 	if config.RunMigrations() {
@@ -30,11 +30,9 @@ var exportUserFeedsCmd = cobra.Command{
 func exportUserFeeds(ctx context.Context, store *storage.Storage,
 	username string,
 ) error {
-	user, err := store.UserByUsername(ctx, username)
+	user, err := userByUsername(ctx, store, username)
 	if err != nil {
-		return fmt.Errorf("unable to find user: %w", err)
-	} else if user == nil {
-		return fmt.Errorf("user %q not found", username)
+		return err
 	}
 
 	opmlExport, err := opml.NewHandler(store).Export(ctx, user.ID)
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/storage"
+)
+
+var (
+	flagFilterFeedID     int64
+	flagFilterCategoryID int64
+)
+
+var filterEntriesCmd = cobra.Command{
+	Use:   "filter-entries username",
+	Short: "Apply filter rules to unread stored entries of the user",
+
+	Args: cobra.ExactArgs(1),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withStorage(
+			func(ctx context.Context, store *storage.Storage) error {
+				return filterEntries(ctx, store, args[0])
+			})
+	},
+}
+
+func init() {
+	filterEntriesCmd.Flags().Int64Var(&flagFilterFeedID, "feed-id", 0,
+		"Only filter entries of this feed")
+	filterEntriesCmd.Flags().Int64Var(&flagFilterCategoryID, "category-id", 0,
+		"Only filter entries of feeds in this category")
+	filterEntriesCmd.MarkFlagsMutuallyExclusive("feed-id", "category-id")
+}
+
+func filterEntries(ctx context.Context, store *storage.Storage,
+	username string,
+) error {
+	user, err := store.UserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("unable to find user: %w", err)
+	} else if user == nil {
+		return fmt.Errorf("user %q not found", username)
+	}
+
+	var feeds model.Feeds
+	switch {
+	case flagFilterFeedID != 0:
+		feed, err := store.FeedByID(ctx, user.ID, flagFilterFeedID)
+		if err != nil {
+			return err
+		} else if feed == nil {
+			return fmt.Errorf("feed #%d not found", flagFilterFeedID)
+		}
+		feeds = model.Feeds{feed}
+	case flagFilterCategoryID != 0:
+		feeds, err = store.FeedsByCategory(ctx, user.ID, flagFilterCategoryID)
+	default:
+		feeds, err = store.Feeds(ctx, user.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get feeds: %w", err)
+	}
+
+	changed, err := filter.FilterStoredEntries(ctx, store, user, feeds...)
+	if err != nil {
+		return fmt.Errorf("unable to filter entries: %w", err)
+	}
+
+	fmt.Println("Changed entries:", changed)
+	return nil
+}
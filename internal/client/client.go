@@ -4,10 +4,13 @@
 package client // import "miniflux.app/v2/client"
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -589,6 +592,249 @@ func (c *Client) RefreshCategoryContext(ctx context.Context, categoryID int64) e
 	return err
 }
 
+// Labels gets all labels of the user.
+func (c *Client) Labels(ctx context.Context) ([]model.Label, error) {
+	body, err := c.request.Get(ctx, "/v1/labels")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var labels []model.Label
+	if err := json.NewDecoder(body).Decode(&labels); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return labels, nil
+}
+
+// CreateLabel creates a new label.
+func (c *Client) CreateLabel(ctx context.Context, title string,
+) (*model.Label, error) {
+	return c.label(c.request.Post(ctx, "/v1/labels",
+		&model.LabelRequest{Title: title}))
+}
+
+// UpdateLabel renames a label.
+func (c *Client) UpdateLabel(ctx context.Context, labelID int64,
+	title string,
+) (*model.Label, error) {
+	return c.label(c.request.Put(ctx, fmt.Sprintf("/v1/labels/%d", labelID),
+		&model.LabelRequest{Title: title}))
+}
+
+func (c *Client) label(body io.ReadCloser, err error) (*model.Label, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var label *model.Label
+	if err := json.NewDecoder(body).Decode(&label); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return label, nil
+}
+
+// DeleteLabel removes a label from all entries and deletes it.
+func (c *Client) DeleteLabel(ctx context.Context, labelID int64) error {
+	return c.request.Delete(ctx, fmt.Sprintf("/v1/labels/%d", labelID))
+}
+
+// EntryLabels gets labels of an entry.
+func (c *Client) EntryLabels(ctx context.Context, entryID int64,
+) ([]model.Label, error) {
+	body, err := c.request.Get(ctx, fmt.Sprintf("/v1/entries/%d/labels", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var labels []model.Label
+	if err := json.NewDecoder(body).Decode(&labels); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return labels, nil
+}
+
+// SetEntryLabels replaces labels of an entry.
+func (c *Client) SetEntryLabels(ctx context.Context, entryID int64,
+	labelIDs []int64,
+) error {
+	_, err := c.request.Put(ctx, fmt.Sprintf("/v1/entries/%d/labels", entryID),
+		&model.EntryLabelsRequest{LabelIDs: labelIDs})
+	return err
+}
+
+// SavedSearches gets all saved searches of the user with their unread
+// entries count.
+func (c *Client) SavedSearches(ctx context.Context) ([]model.SavedSearch,
+	error,
+) {
+	body, err := c.request.Get(ctx, "/v1/saved-searches")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var searches []model.SavedSearch
+	if err := json.NewDecoder(body).Decode(&searches); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return searches, nil
+}
+
+// CreateSavedSearch creates a new saved search.
+func (c *Client) CreateSavedSearch(ctx context.Context, title, query string,
+) (*model.SavedSearch, error) {
+	return c.savedSearch(c.request.Post(ctx, "/v1/saved-searches",
+		&model.SavedSearchRequest{Title: title, Query: query}))
+}
+
+// UpdateSavedSearch updates title and query of a saved search.
+func (c *Client) UpdateSavedSearch(ctx context.Context, id int64,
+	title, query string,
+) (*model.SavedSearch, error) {
+	return c.savedSearch(c.request.Put(ctx,
+		fmt.Sprintf("/v1/saved-searches/%d", id),
+		&model.SavedSearchRequest{Title: title, Query: query}))
+}
+
+func (c *Client) savedSearch(body io.ReadCloser, err error,
+) (*model.SavedSearch, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var search *model.SavedSearch
+	if err := json.NewDecoder(body).Decode(&search); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return search, nil
+}
+
+// DeleteSavedSearch deletes a saved search.
+func (c *Client) DeleteSavedSearch(ctx context.Context, id int64) error {
+	return c.request.Delete(ctx, fmt.Sprintf("/v1/saved-searches/%d", id))
+}
+
+// SavedSearchEntries fetches entries matching a saved search.
+func (c *Client) SavedSearchEntries(ctx context.Context, id int64,
+	filter *Filter,
+) (*EntryResultSet, error) {
+	body, err := c.request.Get(ctx, buildFilterQueryString(
+		fmt.Sprintf("/v1/saved-searches/%d/entries", id), filter))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result EntryResultSet
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return &result, nil
+}
+
+// MarkSavedSearchAsRead marks all unread entries matching a saved search as
+// read.
+func (c *Client) MarkSavedSearchAsRead(ctx context.Context, id int64) error {
+	_, err := c.request.Put(ctx,
+		fmt.Sprintf("/v1/saved-searches/%d/mark-all-as-read", id), nil)
+	return err
+}
+
+// SiteRules gets site rules of the user and global site rules.
+func (c *Client) SiteRules(ctx context.Context) (model.SiteRules, error) {
+	body, err := c.request.Get(ctx, "/v1/site-rules")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rules model.SiteRules
+	if err := json.NewDecoder(body).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return rules, nil
+}
+
+// CreateSiteRule creates a new site rule. Only admins can create global site
+// rules.
+func (c *Client) CreateSiteRule(ctx context.Context,
+	r *model.SiteRuleRequest,
+) (*model.SiteRule, error) {
+	return c.siteRule(c.request.Post(ctx, "/v1/site-rules", r))
+}
+
+// UpdateSiteRule updates a site rule. A site rule can't be made global or
+// not global.
+func (c *Client) UpdateSiteRule(ctx context.Context, id int64,
+	r *model.SiteRuleRequest,
+) (*model.SiteRule, error) {
+	return c.siteRule(c.request.Put(ctx, fmt.Sprintf("/v1/site-rules/%d", id),
+		r))
+}
+
+func (c *Client) siteRule(body io.ReadCloser, err error,
+) (*model.SiteRule, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rule *model.SiteRule
+	if err := json.NewDecoder(body).Decode(&rule); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return rule, nil
+}
+
+// DeleteSiteRule deletes a site rule.
+func (c *Client) DeleteSiteRule(ctx context.Context, id int64) error {
+	return c.request.Delete(ctx, fmt.Sprintf("/v1/site-rules/%d", id))
+}
+
+// ExportSiteRules exports site rules of the user, or global site rules, as a
+// YAML document.
+func (c *Client) ExportSiteRules(ctx context.Context, global bool,
+) ([]byte, error) {
+	body, err := c.request.Get(ctx,
+		"/v1/site-rules/export?global="+strconv.FormatBool(global))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return b, nil
+}
+
+// ImportSiteRules creates or replaces site rules of the user, or global site
+// rules, from a YAML document, created by ExportSiteRules. It returns the
+// number of imported site rules.
+func (c *Client) ImportSiteRules(ctx context.Context, global bool,
+	f io.ReadCloser,
+) (int, error) {
+	body, err := c.request.PostFile(ctx,
+		"/v1/site-rules/import?global="+strconv.FormatBool(global), f)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	var result struct {
+		Imported int `json:"imported"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return result.Imported, nil
+}
+
 // Feeds gets all feeds.
 func (c *Client) Feeds() (model.Feeds, error) {
 	ctx, cancel := withDefaultTimeout()
@@ -648,6 +894,75 @@ func (c *Client) ImportContext(ctx context.Context, f io.ReadCloser) error {
 	return err
 }
 
+// Events returns the stream of changes of entries and feed counters, which
+// ends when ctx is done or the server closes the stream.
+func (c *Client) Events(ctx context.Context) iter.Seq2[*model.Event, error] {
+	return func(yield func(*model.Event, error) bool) {
+		body, err := c.request.Get(ctx, "/v1/events")
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer body.Close()
+
+		var data []byte
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if b, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+				data = append(data, bytes.TrimSpace(b)...)
+				continue
+			} else if len(line) != 0 || len(data) == 0 {
+				continue
+			}
+
+			var event model.Event
+			if err := json.Unmarshal(data, &event); err != nil {
+				yield(nil, fmt.Errorf("miniflux: response error (%w)", err))
+				return
+			} else if !yield(&event, nil) {
+				return
+			}
+			data = data[:0]
+		}
+
+		if err := scanner.Err(); err != nil && ctx.Err() == nil {
+			yield(nil, fmt.Errorf("miniflux: response error (%w)", err))
+		}
+	}
+}
+
+// ExportAccount exports the full account as a zip file.
+func (c *Client) ExportAccount(ctx context.Context) ([]byte, error) {
+	body, err := c.request.Get(ctx, "/v1/export/account")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return b, nil
+}
+
+// ImportAccount restores an account archive, created by ExportAccount.
+func (c *Client) ImportAccount(ctx context.Context, f io.ReadCloser,
+) (*model.AccountRestoreResult, error) {
+	body, err := c.request.PostFile(ctx, "/v1/import/account", f)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result model.AccountRestoreResult
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return &result, nil
+}
+
 // Feed gets a feed.
 func (c *Client) Feed(feedID int64) (*model.Feed, error) {
 	ctx, cancel := withDefaultTimeout()
@@ -721,6 +1036,33 @@ func (c *Client) UpdateFeedContext(ctx context.Context, feedID int64, feedChange
 	return f, nil
 }
 
+// UpdateFeeds applies the same changes to several feeds.
+func (c *Client) UpdateFeeds(ctx context.Context, feedIDs []int64,
+	feedChanges *model.FeedModificationRequest,
+) (model.Feeds, error) {
+	body, err := c.request.Put(ctx, "/v1/feeds", &model.FeedsModificationRequest{
+		FeedIDs: feedIDs,
+		Changes: *feedChanges,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var feeds model.Feeds
+	if err := json.NewDecoder(body).Decode(&feeds); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return feeds, nil
+}
+
+// RemoveFeeds removes several feeds.
+func (c *Client) RemoveFeeds(ctx context.Context, feedIDs []int64) error {
+	_, err := c.request.Post(ctx, "/v1/feeds/remove",
+		&model.FeedsRemovalRequest{FeedIDs: feedIDs})
+	return err
+}
+
 func (c *Client) ImportEntries(ctx context.Context, req *model.ImportEntries,
 ) (*EntryResultSet, error) {
 	body, err := c.request.Post(ctx, "/v1/import/entries", req)
@@ -736,6 +1078,130 @@ func (c *Client) ImportEntries(ctx context.Context, req *model.ImportEntries,
 	return res, nil
 }
 
+// ImportEntriesFrom imports entries of an Atom, RSS or JSON Feed document or
+// of a Google Reader export, creating their feeds if needed.
+func (c *Client) ImportEntriesFrom(ctx context.Context, f io.ReadCloser,
+	opts *ImportEntriesOptions,
+) (*EntryResultSet, error) {
+	values := url.Values{}
+	if opts != nil {
+		if opts.FeedURL != "" {
+			values.Set("feed_url", opts.FeedURL)
+		}
+		if opts.CategoryID != 0 {
+			values.Set("category_id", strconv.FormatInt(opts.CategoryID, 10))
+		}
+		if opts.Status != "" {
+			values.Set("status", opts.Status)
+		}
+		if opts.Starred {
+			values.Set("starred", "true")
+		}
+	}
+
+	path := "/v1/import/entries"
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	body, err := c.request.PostFile(ctx, path, f)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	res := &EntryResultSet{}
+	if err := json.NewDecoder(body).Decode(res); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return res, nil
+}
+
+// PreviewFeedFilter reports what candidate filter rules would do with entries
+// of the feed.
+func (c *Client) PreviewFeedFilter(ctx context.Context, feedID int64,
+	req *model.FilterPreviewRequest,
+) (*model.FilterPreview, error) {
+	body, err := c.request.Post(ctx,
+		fmt.Sprintf("/v1/feeds/%d/filter-preview", feedID), req)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var preview *model.FilterPreview
+	if err := json.NewDecoder(body).Decode(&preview); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return preview, nil
+}
+
+// ApplyFeedFilters applies filter rules to stored entries of the feed and
+// returns the number of changed entries.
+func (c *Client) ApplyFeedFilters(ctx context.Context, feedID int64,
+) (int, error) {
+	return c.applyFilters(ctx, fmt.Sprintf("/v1/feeds/%d/apply-filters", feedID))
+}
+
+// ApplyCategoryFilters applies filter rules to stored entries of feeds in the
+// category and returns the number of changed entries.
+func (c *Client) ApplyCategoryFilters(ctx context.Context, categoryID int64,
+) (int, error) {
+	return c.applyFilters(ctx,
+		fmt.Sprintf("/v1/categories/%d/apply-filters", categoryID))
+}
+
+// ApplyAllFilters applies filter rules to stored entries of all feeds and
+// returns the number of changed entries.
+func (c *Client) ApplyAllFilters(ctx context.Context) (int, error) {
+	return c.applyFilters(ctx, "/v1/feeds/apply-filters")
+}
+
+func (c *Client) applyFilters(ctx context.Context, path string) (int, error) {
+	body, err := c.request.Post(ctx, path, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	var result struct {
+		Changed int `json:"changed"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return result.Changed, nil
+}
+
+// FilterRuleHits returns hits of block and keep rules of the user for every
+// feed.
+func (c *Client) FilterRuleHits(ctx context.Context) ([]model.FilterRuleHit,
+	error,
+) {
+	return c.filterRuleHits(ctx, "/v1/filter-hits")
+}
+
+// FeedFilterRuleHits returns hits of block and keep rules for the feed.
+func (c *Client) FeedFilterRuleHits(ctx context.Context, feedID int64,
+) ([]model.FilterRuleHit, error) {
+	return c.filterRuleHits(ctx, fmt.Sprintf("/v1/feeds/%d/filter-hits", feedID))
+}
+
+func (c *Client) filterRuleHits(ctx context.Context, path string,
+) ([]model.FilterRuleHit, error) {
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var hits []model.FilterRuleHit
+	if err := json.NewDecoder(body).Decode(&hits); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return hits, nil
+}
+
 // MarkFeedAsRead marks all unread entries of the feed as read.
 func (c *Client) MarkFeedAsRead(feedID int64) error {
 	ctx, cancel := withDefaultTimeout()
@@ -1113,6 +1579,37 @@ func (c *Client) FetchEntryOriginalContentContext(ctx context.Context, entryID i
 	return response.Content, nil
 }
 
+// EntryScraperDiagnostics scrapes the web page of an entry and reports how its
+// content was extracted, without changing the entry.
+func (c *Client) EntryScraperDiagnostics(ctx context.Context, entryID int64,
+) (*model.ScraperDiagnostics, error) {
+	return c.scraperDiagnostics(ctx,
+		fmt.Sprintf("/v1/entries/%d/scraper-diagnostics", entryID))
+}
+
+// ScraperDiagnostics scrapes a web page and reports how its content was
+// extracted.
+func (c *Client) ScraperDiagnostics(ctx context.Context, pageURL string,
+) (*model.ScraperDiagnostics, error) {
+	return c.scraperDiagnostics(ctx,
+		"/v1/scraper-diagnostics?url="+url.QueryEscape(pageURL))
+}
+
+func (c *Client) scraperDiagnostics(ctx context.Context, path string,
+) (*model.ScraperDiagnostics, error) {
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var d model.ScraperDiagnostics
+	if err := json.NewDecoder(body).Decode(&d); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%w)", err)
+	}
+	return &d, nil
+}
+
 // FetchCounters fetches feed counters.
 func (c *Client) FetchCounters() (*model.FeedCounters, error) {
 	ctx, cancel := withDefaultTimeout()
@@ -1262,6 +1759,10 @@ func buildFilterQueryString(path string, filter *Filter) string {
 		values.Set("feed_id", strconv.FormatInt(filter.FeedID, 10))
 	}
 
+	if filter.LabelID > 0 {
+		values.Set("label_id", strconv.FormatInt(filter.LabelID, 10))
+	}
+
 	if filter.GloballyVisible {
 		values.Set("globally_visible", "true")
 	}
@@ -54,6 +54,7 @@ type Filter struct {
 	Search          string
 	CategoryID      int64
 	FeedID          int64
+	LabelID         int64
 	Statuses        []string
 	Tags            []string
 	GloballyVisible bool
@@ -65,6 +66,15 @@ type EntryResultSet struct {
 	Entries model.Entries `json:"entries"`
 }
 
+// ImportEntriesOptions holds optional parameters of entries imported from a
+// feed document or a Google Reader export.
+type ImportEntriesOptions struct {
+	FeedURL    string
+	CategoryID int64
+	Status     string
+	Starred    bool
+}
+
 // EntryIDsFilter holds optional filter and pagination parameters for the entry IDs endpoint.
 type EntryIDsFilter struct {
 	Limit   int
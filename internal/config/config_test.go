@@ -302,6 +302,20 @@ func TestCleanupArchiveReadDays(t *testing.T) {
 	assert.Equal(t, 7, opts.env.CleanupArchiveReadDays)
 }
 
+func TestCleanupRemovedEntriesDays(t *testing.T) {
+	os.Clearenv()
+	opts := parseEnvironmentVariables(t)
+	assert.Equal(t, 7, opts.env.CleanupCompactRemovedDays)
+
+	t.Setenv("CLEANUP_COMPACT_REMOVED_DAYS", "0")
+	opts = parseEnvironmentVariables(t)
+	assert.Zero(t, opts.env.CleanupCompactRemovedDays)
+
+	t.Setenv("CLEANUP_COMPACT_REMOVED_DAYS", "-1")
+	opts = parseEnvironmentVariables(t)
+	assert.Equal(t, -1, opts.env.CleanupCompactRemovedDays)
+}
+
 func TestDefaultCleanupRemoveSessionsDaysValue(t *testing.T) {
 	os.Clearenv()
 	opts := parseEnvironmentVariables(t)
@@ -396,6 +410,31 @@ func TestSchedulerRoundRobinMaxInterval(t *testing.T) {
 	assert.Equal(t, 150, opts.env.SchedulerRoundRobinMaxInterval)
 }
 
+func TestDefaultPollingSchedulerValue(t *testing.T) {
+	os.Clearenv()
+	opts := parseEnvironmentVariables(t)
+	assert.Equal(t, SchedulerRoundRobin, opts.env.PollingScheduler)
+}
+
+func TestPollingScheduler(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	t.Setenv("SCHEDULER_ENTRY_FREQUENCY_FACTOR", "2")
+	t.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "10")
+	t.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", "2880")
+	opts := parseEnvironmentVariables(t)
+	assert.Equal(t, SchedulerEntryFrequency, opts.env.PollingScheduler)
+	assert.Equal(t, 2, opts.env.SchedulerEntryFrequencyFactor)
+	assert.Equal(t, 10, opts.env.SchedulerEntryFrequencyMin)
+	assert.Equal(t, 2880, opts.env.SchedulerEntryFrequencyMax)
+}
+
+func TestPollingSchedulerWithInvalidValue(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("POLLING_SCHEDULER", "invalid")
+	require.Error(t, Load(""))
+}
+
 func TestPollingParsingErrorLimit(t *testing.T) {
 	os.Clearenv()
 	t.Setenv("POLLING_PARSING_ERROR_LIMIT", "100")
@@ -933,6 +972,34 @@ func TestFetcherAllowPrivateNetworks(t *testing.T) {
 	assert.False(t, FetcherAllowPrivateNetworks())
 }
 
+func TestRenderService(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, Load(""))
+	assert.Nil(t, RenderServiceURL())
+	assert.Equal(t, RenderServiceBrowserless, RenderServiceAPI())
+	assert.False(t, RenderServiceDomain("example.org"))
+
+	t.Setenv("RENDER_SERVICE_URL", "http://splash:8050/render.html")
+	t.Setenv("RENDER_SERVICE_API", "splash")
+	t.Setenv("RENDER_SERVICE_DOMAINS", "example.org,Example.com")
+	require.NoError(t, Load(""))
+	require.NotNil(t, RenderServiceURL())
+	assert.Equal(t, "http://splash:8050/render.html",
+		RenderServiceURL().String())
+	assert.Equal(t, RenderServiceSplash, RenderServiceAPI())
+	assert.True(t, RenderServiceDomain("example.org"))
+	assert.True(t, RenderServiceDomain("www.EXAMPLE.com"))
+	assert.False(t, RenderServiceDomain("notexample.org"))
+	assert.False(t, RenderServiceDomain("example.net"))
+}
+
+func TestInvalidRenderServiceAPI(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("RENDER_SERVICE_API", "puppeteer")
+	_, err := NewParser().ParseEnvironmentVariables()
+	require.ErrorContains(t, err, "RENDER_SERVICE_API")
+}
+
 func TestLoadYAML(t *testing.T) {
 	require.Error(t, LoadYAML("testdata/notfound.yaml", ""))
 	require.Error(t, LoadYAML("", "testdata/notfound.env"))
@@ -19,6 +19,16 @@ import (
 	"miniflux.app/v2/internal/version"
 )
 
+const (
+	SchedulerRoundRobin     = "round_robin"
+	SchedulerEntryFrequency = "entry_frequency"
+)
+
+const (
+	RenderServiceBrowserless = "browserless"
+	RenderServiceSplash      = "splash"
+)
+
 const (
 	defaultBaseURL     = "http://localhost"
 	defaultDatabaseURL = "user=postgres password=postgres dbname=miniflux2 sslmode=disable"
@@ -76,6 +86,7 @@ type envOptions struct {
 	CleanupArchiveBatchSize        int      `env:"CLEANUP_ARCHIVE_BATCH_SIZE" validate:"min=1"`
 	CleanupArchiveReadDays         int      `env:"CLEANUP_ARCHIVE_READ_DAYS" validate:"min=0"`
 	CleanupArchiveUnreadDays       int      `env:"CLEANUP_ARCHIVE_UNREAD_DAYS" validate:"min=0"`
+	CleanupCompactRemovedDays      int      `env:"CLEANUP_COMPACT_REMOVED_DAYS" validate:"min=-1"`
 	CleanupFrequencyHours          int      `env:"CLEANUP_FREQUENCY_HOURS" validate:"min=1"`
 	CleanupInactiveSessionsDays    int      `env:"CLEANUP_INACTIVE_SESSIONS_DAYS" validate:"min=0"`
 	CleanupRemoveSessionsDays      int      `env:"CLEANUP_REMOVE_SESSIONS_DAYS" validate:"min=0"`
@@ -139,10 +150,17 @@ type envOptions struct {
 	OidcProviderName               string   `env:"OAUTH2_OIDC_PROVIDER_NAME"`
 	Operators                      []string `env:"OPERATORS"`
 	PollingFrequency               int      `env:"POLLING_FREQUENCY" validate:"min=1"`
+	PollingScheduler               string   `env:"POLLING_SCHEDULER" validate:"required,oneof=round_robin entry_frequency"`
 	Port                           string   `env:"PORT"`
 	PreferSiteIcon                 bool     `env:"PREFER_SITE_ICON"`
 	RateLimitPerServer             float64  `env:"RATE_LIMIT_PER_SERVER" validate:"min=0"`
+	RenderServiceAPI               string   `env:"RENDER_SERVICE_API" validate:"required,oneof=browserless splash"`
+	RenderServiceDomains           []string `env:"RENDER_SERVICE_DOMAINS" validate:"dive,required,hostname"`
+	RenderServiceURL               *url.URL `env:"RENDER_SERVICE_URL"`
 	RunMigrations                  bool     `env:"RUN_MIGRATIONS"`
+	SchedulerEntryFrequencyFactor  int      `env:"SCHEDULER_ENTRY_FREQUENCY_FACTOR" validate:"min=1"`
+	SchedulerEntryFrequencyMax     int      `env:"SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL" validate:"min=1"`
+	SchedulerEntryFrequencyMin     int      `env:"SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL" validate:"min=1,ltefield=SchedulerEntryFrequencyMax"`
 	SchedulerRoundRobinMaxInterval int      `env:"SCHEDULER_ROUND_ROBIN_MAX_INTERVAL" validate:"min=1"`
 	SchedulerRoundRobinMinInterval int      `env:"SCHEDULER_ROUND_ROBIN_MIN_INTERVAL" validate:"min=1,ltefield=SchedulerRoundRobinMaxInterval"`
 	Testing                        bool     `env:"TESTING"`
@@ -188,13 +206,18 @@ func NewOptions() *options {
 			CleanupArchiveReadDays:         60,
 			CleanupArchiveUnreadDays:       180,
 			CleanupArchiveBatchSize:        10000,
+			CleanupCompactRemovedDays:      7,
 			CleanupRemoveSessionsDays:      30,
 			CleanupInactiveSessionsDays:    10,
 			PollingFrequency:               60,
+			PollingScheduler:               SchedulerRoundRobin,
 			ForceRefreshInterval:           30,
 			BatchSize:                      100,
 			SchedulerRoundRobinMinInterval: 60,
 			SchedulerRoundRobinMaxInterval: 1440,
+			SchedulerEntryFrequencyFactor:  1,
+			SchedulerEntryFrequencyMin:     5,
+			SchedulerEntryFrequencyMax:     1440,
 			PollingErrorLimit:              3,
 			WorkerPoolSize:                 16,
 			MediaProxyHTTPClientTimeout:    120,
@@ -213,6 +236,7 @@ func NewOptions() *options {
 			InvidiousInstance:              "yewtu.be",
 			ConnectionsPerServer:           8,
 			RateLimitPerServer:             10,
+			RenderServiceAPI:               RenderServiceBrowserless,
 			TrustedProxies:                 []string{"127.0.0.1"},
 
 			FetcherDenyNetworks: []netip.Prefix{
@@ -378,6 +402,20 @@ func (o *options) sortedOptions(redactSecret bool) []Option {
 		}
 	}
 
+	var renderServiceURLRedacted string
+	if o.env.RenderServiceURL != nil {
+		if redactSecret {
+			// Browserless expects its token in the query string.
+			u := *o.env.RenderServiceURL
+			if u.RawQuery != "" {
+				u.RawQuery = "redacted"
+			}
+			renderServiceURLRedacted = u.Redacted()
+		} else {
+			renderServiceURLRedacted = o.env.RenderServiceURL.String()
+		}
+	}
+
 	var clientProxyURLsRedacted string
 	if len(o.env.HttpClientProxies) > 0 {
 		if redactSecret {
@@ -402,91 +440,99 @@ func (o *options) sortedOptions(redactSecret bool) []Option {
 	}
 
 	keyValues := map[string]any{
-		"ADMIN_PASSWORD":                     secretValue(o.env.AdminPassword, redactSecret),
-		"ADMIN_USERNAME":                     o.env.AdminUsername,
-		"AUTH_PROXY_HEADER":                  o.env.AuthProxyHeader,
-		"AUTH_PROXY_USER_CREATION":           o.env.AuthProxyUserCreation,
-		"BASE_PATH":                          o.basePath,
-		"BASE_URL":                           o.env.BaseURL,
-		"BATCH_SIZE":                         o.env.BatchSize,
-		"BLOCK_MARK_READ":                    o.env.BlockMarkRead,
-		"CERT_DOMAIN":                        o.env.CertDomain,
-		"CERT_FILE":                          o.env.CertFile,
-		"CLEANUP_ARCHIVE_BATCH_SIZE":         o.env.CleanupArchiveBatchSize,
-		"CLEANUP_ARCHIVE_READ_DAYS":          o.env.CleanupArchiveReadDays,
-		"CLEANUP_ARCHIVE_UNREAD_DAYS":        o.env.CleanupArchiveUnreadDays,
-		"CLEANUP_FREQUENCY_HOURS":            o.env.CleanupFrequencyHours,
-		"CLEANUP_INACTIVE_SESSIONS_DAYS":     o.env.CleanupInactiveSessionsDays,
-		"CLEANUP_REMOVE_SESSIONS_DAYS":       o.env.CleanupRemoveSessionsDays,
-		"CONNECTIONS_PER_SERVER":             o.env.ConnectionsPerServer,
-		"CREATE_ADMIN":                       o.env.CreateAdmin,
-		"DATABASE_CONNECTION_LIFETIME":       o.env.DatabaseConnectionLifetime,
-		"DATABASE_MAX_CONNS":                 o.env.DatabaseMaxConns,
-		"DATABASE_MIN_CONNS":                 o.env.DatabaseMinConns,
-		"DATABASE_URL":                       secretValue(o.env.DatabaseURL, redactSecret),
-		"DISABLE_API":                        o.env.DisableAPI,
-		"DISABLE_HSTS":                       o.env.DisableHSTS,
-		"DISABLE_HTTP_SERVICE":               o.env.DisableHttpService,
-		"DISABLE_LOCAL_AUTH":                 o.env.DisableLocalAuth,
-		"DISABLE_SCHEDULER_SERVICE":          o.env.DisableScheduler,
-		"FETCH_BILIBILI_WATCH_TIME":          o.env.FetchBilibiliWatchTime,
-		"FETCH_NEBULA_WATCH_TIME":            o.env.FetchNebulaWatchTime,
-		"FETCH_ODYSEE_WATCH_TIME":            o.env.FetchOdyseeWatchTime,
-		"FETCH_YOUTUBE_WATCH_TIME":           o.env.FetchYouTubeWatchTime,
-		"FETCHER_ALLOW_PRIVATE_HOSTS":        strings.Join(o.env.FetcherAllowPrivateHosts, ","),
-		"FETCHER_ALLOW_PRIVATE_NETWORKS":     o.env.FetcherAllowPrivateNets,
-		"FETCHER_DENY_NETWORKS":              strings.Join(fetcherDenyNetworks, ","),
-		"FILTER_ENTRY_MAX_AGE_DAYS":          o.env.FilterEntryMaxAgeDays,
-		"FORCE_REFRESH_INTERVAL":             o.env.ForceRefreshInterval,
-		"HTTPS":                              !o.env.DisableHSTS,
-		"HTTP_CLIENT_MAX_BODY_SIZE":          o.env.HttpClientMaxBodySize,
-		"HTTP_CLIENT_PROXIES":                clientProxyURLsRedacted,
-		"HTTP_CLIENT_PROXY":                  clientProxyURLRedacted,
-		"HTTP_CLIENT_TIMEOUT":                o.env.HttpClientTimeout,
-		"HTTP_CLIENT_USER_AGENT":             o.env.HttpClientUserAgent,
-		"HTTP_SERVER_TIMEOUT":                o.env.HttpServerTimeout,
-		"HTTP_SERVICE":                       !o.env.DisableHttpService,
-		"INVIDIOUS_INSTANCE":                 o.env.InvidiousInstance,
-		"KEY_FILE":                           o.env.CertKeyFile,
-		"LISTEN_ADDR":                        o.env.ListenAddr,
-		"LOG_DATE_TIME":                      o.env.LogDateTime,
-		"LOG_FILE":                           o.env.LogFile,
-		"LOG_FORMAT":                         o.env.LogFormat,
-		"LOG_LEVEL":                          o.env.LogLevel,
-		"MAINTENANCE_MESSAGE":                o.env.MaintenanceMessage,
-		"MAINTENANCE_MODE":                   o.env.MaintenanceMode,
-		"MEDIA_PROXY_CUSTOM_URL":             o.env.MediaProxyCustomURL,
-		"MEDIA_PROXY_HTTP_CLIENT_TIMEOUT":    o.env.MediaProxyHTTPClientTimeout,
-		"MEDIA_PROXY_MODE":                   o.env.MediaProxyMode,
-		"MEDIA_PROXY_PRIVATE_KEY":            mediaProxyPrivateKeyValue,
-		"MEDIA_PROXY_RESOURCE_TYPES":         strings.Join(o.env.MediaProxyResourceTypes, ","),
-		"METRICS_ALLOWED_NETWORKS":           strings.Join(o.env.MetricsAllowedNetworks, ","),
-		"METRICS_COLLECTOR":                  o.env.MetricsCollector,
-		"METRICS_PASSWORD":                   secretValue(o.env.MetricsPassword, redactSecret),
-		"METRICS_REFRESH_INTERVAL":           o.env.MetricsRefreshInterval,
-		"METRICS_USERNAME":                   o.env.MetricsUsername,
-		"OAUTH2_CLIENT_ID":                   o.env.Oauth2ClientID,
-		"OAUTH2_CLIENT_SECRET":               secretValue(o.env.Oauth2ClientSecret, redactSecret),
-		"OAUTH2_OIDC_DISCOVERY_ENDPOINT":     o.env.OidcDiscoveryEndpoint,
-		"OAUTH2_OIDC_PROVIDER_NAME":          o.env.OidcProviderName,
-		"OAUTH2_PROVIDER":                    o.env.Oauth2Provider,
-		"OAUTH2_REDIRECT_URL":                o.env.Oauth2RedirectURL,
-		"OAUTH2_USER_CREATION":               o.env.Oauth2UserCreationAllowed,
-		"POLLING_FREQUENCY":                  o.env.PollingFrequency,
-		"POLLING_PARSING_ERROR_LIMIT":        o.env.PollingErrorLimit,
-		"PREFER_SITE_ICON":                   o.env.PreferSiteIcon,
-		"RATE_LIMIT_PER_SERVER":              o.env.RateLimitPerServer,
-		"ROOT_URL":                           o.rootURL,
-		"RUN_MIGRATIONS":                     o.env.RunMigrations,
-		"SCHEDULER_ROUND_ROBIN_MAX_INTERVAL": o.env.SchedulerRoundRobinMaxInterval,
-		"SCHEDULER_ROUND_ROBIN_MIN_INTERVAL": o.env.SchedulerRoundRobinMinInterval,
-		"SCHEDULER_SERVICE":                  !o.env.DisableScheduler,
-		"TRUSTED_PROXIES":                    strings.Join(o.env.TrustedProxies, ","),
-		"WATCHDOG":                           o.env.Watchdog,
-		"WEBAUTHN":                           o.env.WebAuthn,
-		"WORKER_POOL_SIZE":                   o.env.WorkerPoolSize,
-		"YOUTUBE_API_KEY":                    secretValue(o.env.YouTubeApiKey, redactSecret),
-		"YOUTUBE_EMBED_URL_OVERRIDE":         o.env.YouTubeEmbedUrlOverride.String(),
+		"ADMIN_PASSWORD":                         secretValue(o.env.AdminPassword, redactSecret),
+		"ADMIN_USERNAME":                         o.env.AdminUsername,
+		"AUTH_PROXY_HEADER":                      o.env.AuthProxyHeader,
+		"AUTH_PROXY_USER_CREATION":               o.env.AuthProxyUserCreation,
+		"BASE_PATH":                              o.basePath,
+		"BASE_URL":                               o.env.BaseURL,
+		"BATCH_SIZE":                             o.env.BatchSize,
+		"BLOCK_MARK_READ":                        o.env.BlockMarkRead,
+		"CERT_DOMAIN":                            o.env.CertDomain,
+		"CERT_FILE":                              o.env.CertFile,
+		"CLEANUP_ARCHIVE_BATCH_SIZE":             o.env.CleanupArchiveBatchSize,
+		"CLEANUP_ARCHIVE_READ_DAYS":              o.env.CleanupArchiveReadDays,
+		"CLEANUP_ARCHIVE_UNREAD_DAYS":            o.env.CleanupArchiveUnreadDays,
+		"CLEANUP_COMPACT_REMOVED_DAYS":           o.env.CleanupCompactRemovedDays,
+		"CLEANUP_FREQUENCY_HOURS":                o.env.CleanupFrequencyHours,
+		"CLEANUP_INACTIVE_SESSIONS_DAYS":         o.env.CleanupInactiveSessionsDays,
+		"CLEANUP_REMOVE_SESSIONS_DAYS":           o.env.CleanupRemoveSessionsDays,
+		"CONNECTIONS_PER_SERVER":                 o.env.ConnectionsPerServer,
+		"CREATE_ADMIN":                           o.env.CreateAdmin,
+		"DATABASE_CONNECTION_LIFETIME":           o.env.DatabaseConnectionLifetime,
+		"DATABASE_MAX_CONNS":                     o.env.DatabaseMaxConns,
+		"DATABASE_MIN_CONNS":                     o.env.DatabaseMinConns,
+		"DATABASE_URL":                           secretValue(o.env.DatabaseURL, redactSecret),
+		"DISABLE_API":                            o.env.DisableAPI,
+		"DISABLE_HSTS":                           o.env.DisableHSTS,
+		"DISABLE_HTTP_SERVICE":                   o.env.DisableHttpService,
+		"DISABLE_LOCAL_AUTH":                     o.env.DisableLocalAuth,
+		"DISABLE_SCHEDULER_SERVICE":              o.env.DisableScheduler,
+		"FETCH_BILIBILI_WATCH_TIME":              o.env.FetchBilibiliWatchTime,
+		"FETCH_NEBULA_WATCH_TIME":                o.env.FetchNebulaWatchTime,
+		"FETCH_ODYSEE_WATCH_TIME":                o.env.FetchOdyseeWatchTime,
+		"FETCH_YOUTUBE_WATCH_TIME":               o.env.FetchYouTubeWatchTime,
+		"FETCHER_ALLOW_PRIVATE_HOSTS":            strings.Join(o.env.FetcherAllowPrivateHosts, ","),
+		"FETCHER_ALLOW_PRIVATE_NETWORKS":         o.env.FetcherAllowPrivateNets,
+		"FETCHER_DENY_NETWORKS":                  strings.Join(fetcherDenyNetworks, ","),
+		"FILTER_ENTRY_MAX_AGE_DAYS":              o.env.FilterEntryMaxAgeDays,
+		"FORCE_REFRESH_INTERVAL":                 o.env.ForceRefreshInterval,
+		"HTTPS":                                  !o.env.DisableHSTS,
+		"HTTP_CLIENT_MAX_BODY_SIZE":              o.env.HttpClientMaxBodySize,
+		"HTTP_CLIENT_PROXIES":                    clientProxyURLsRedacted,
+		"HTTP_CLIENT_PROXY":                      clientProxyURLRedacted,
+		"HTTP_CLIENT_TIMEOUT":                    o.env.HttpClientTimeout,
+		"HTTP_CLIENT_USER_AGENT":                 o.env.HttpClientUserAgent,
+		"HTTP_SERVER_TIMEOUT":                    o.env.HttpServerTimeout,
+		"HTTP_SERVICE":                           !o.env.DisableHttpService,
+		"INVIDIOUS_INSTANCE":                     o.env.InvidiousInstance,
+		"KEY_FILE":                               o.env.CertKeyFile,
+		"LISTEN_ADDR":                            o.env.ListenAddr,
+		"LOG_DATE_TIME":                          o.env.LogDateTime,
+		"LOG_FILE":                               o.env.LogFile,
+		"LOG_FORMAT":                             o.env.LogFormat,
+		"LOG_LEVEL":                              o.env.LogLevel,
+		"MAINTENANCE_MESSAGE":                    o.env.MaintenanceMessage,
+		"MAINTENANCE_MODE":                       o.env.MaintenanceMode,
+		"MEDIA_PROXY_CUSTOM_URL":                 o.env.MediaProxyCustomURL,
+		"MEDIA_PROXY_HTTP_CLIENT_TIMEOUT":        o.env.MediaProxyHTTPClientTimeout,
+		"MEDIA_PROXY_MODE":                       o.env.MediaProxyMode,
+		"MEDIA_PROXY_PRIVATE_KEY":                mediaProxyPrivateKeyValue,
+		"MEDIA_PROXY_RESOURCE_TYPES":             strings.Join(o.env.MediaProxyResourceTypes, ","),
+		"METRICS_ALLOWED_NETWORKS":               strings.Join(o.env.MetricsAllowedNetworks, ","),
+		"METRICS_COLLECTOR":                      o.env.MetricsCollector,
+		"METRICS_PASSWORD":                       secretValue(o.env.MetricsPassword, redactSecret),
+		"METRICS_REFRESH_INTERVAL":               o.env.MetricsRefreshInterval,
+		"METRICS_USERNAME":                       o.env.MetricsUsername,
+		"OAUTH2_CLIENT_ID":                       o.env.Oauth2ClientID,
+		"OAUTH2_CLIENT_SECRET":                   secretValue(o.env.Oauth2ClientSecret, redactSecret),
+		"OAUTH2_OIDC_DISCOVERY_ENDPOINT":         o.env.OidcDiscoveryEndpoint,
+		"OAUTH2_OIDC_PROVIDER_NAME":              o.env.OidcProviderName,
+		"OAUTH2_PROVIDER":                        o.env.Oauth2Provider,
+		"OAUTH2_REDIRECT_URL":                    o.env.Oauth2RedirectURL,
+		"OAUTH2_USER_CREATION":                   o.env.Oauth2UserCreationAllowed,
+		"POLLING_FREQUENCY":                      o.env.PollingFrequency,
+		"POLLING_PARSING_ERROR_LIMIT":            o.env.PollingErrorLimit,
+		"POLLING_SCHEDULER":                      o.env.PollingScheduler,
+		"PREFER_SITE_ICON":                       o.env.PreferSiteIcon,
+		"RATE_LIMIT_PER_SERVER":                  o.env.RateLimitPerServer,
+		"RENDER_SERVICE_API":                     o.env.RenderServiceAPI,
+		"RENDER_SERVICE_DOMAINS":                 strings.Join(o.env.RenderServiceDomains, ","),
+		"RENDER_SERVICE_URL":                     renderServiceURLRedacted,
+		"ROOT_URL":                               o.rootURL,
+		"RUN_MIGRATIONS":                         o.env.RunMigrations,
+		"SCHEDULER_ENTRY_FREQUENCY_FACTOR":       o.env.SchedulerEntryFrequencyFactor,
+		"SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL": o.env.SchedulerEntryFrequencyMax,
+		"SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL": o.env.SchedulerEntryFrequencyMin,
+		"SCHEDULER_ROUND_ROBIN_MAX_INTERVAL":     o.env.SchedulerRoundRobinMaxInterval,
+		"SCHEDULER_ROUND_ROBIN_MIN_INTERVAL":     o.env.SchedulerRoundRobinMinInterval,
+		"SCHEDULER_SERVICE":                      !o.env.DisableScheduler,
+		"TRUSTED_PROXIES":                        strings.Join(o.env.TrustedProxies, ","),
+		"WATCHDOG":                               o.env.Watchdog,
+		"WEBAUTHN":                               o.env.WebAuthn,
+		"WORKER_POOL_SIZE":                       o.env.WorkerPoolSize,
+		"YOUTUBE_API_KEY":                        secretValue(o.env.YouTubeApiKey, redactSecret),
+		"YOUTUBE_EMBED_URL_OVERRIDE":             o.env.YouTubeEmbedUrlOverride.String(),
 	}
 
 	sortedKeys := slices.Sorted(maps.Keys(keyValues))
@@ -634,6 +680,12 @@ func CleanupArchiveReadDays() int { return opts.env.CleanupArchiveReadDays }
 // unread items as removed.
 func CleanupArchiveUnreadDays() int { return opts.env.CleanupArchiveUnreadDays }
 
+// CleanupCompactRemovedDays returns the number of days after which the
+// content of removed entries is cleared. -1 disables compaction.
+func CleanupCompactRemovedDays() int {
+	return opts.env.CleanupCompactRemovedDays
+}
+
 // CleanupArchiveBatchSize returns the number of entries to archive for each
 // interval.
 func CleanupArchiveBatchSize() int { return opts.env.CleanupArchiveBatchSize }
@@ -678,6 +730,27 @@ func SchedulerRoundRobinMaxInterval() int {
 	return opts.env.SchedulerRoundRobinMaxInterval
 }
 
+// PollingScheduler returns the scheduler used for polling feeds.
+func PollingScheduler() string { return opts.env.PollingScheduler }
+
+// SchedulerEntryFrequencyFactor returns the factor to increase refresh
+// frequency for the entry frequency scheduler.
+func SchedulerEntryFrequencyFactor() int {
+	return opts.env.SchedulerEntryFrequencyFactor
+}
+
+// SchedulerEntryFrequencyMinInterval returns the minimum interval in minutes
+// for the entry frequency scheduler.
+func SchedulerEntryFrequencyMinInterval() int {
+	return opts.env.SchedulerEntryFrequencyMin
+}
+
+// SchedulerEntryFrequencyMaxInterval returns the maximum interval in minutes
+// for the entry frequency scheduler.
+func SchedulerEntryFrequencyMaxInterval() int {
+	return opts.env.SchedulerEntryFrequencyMax
+}
+
 // PollingErrorLimit returns the limit of errors when to stop polling.
 func PollingErrorLimit() int { return opts.env.PollingErrorLimit }
 
@@ -875,6 +948,31 @@ func ConnectionsPerServer() int64 { return opts.env.ConnectionsPerServer }
 
 func RateLimitPerServer() float64 { return opts.env.RateLimitPerServer }
 
+// RenderServiceURL returns the endpoint of the render service, which renders
+// web pages with JavaScript, or nil if it isn't configured.
+func RenderServiceURL() *url.URL { return opts.env.RenderServiceURL }
+
+// RenderServiceAPI returns the HTTP API of the render service:
+// RenderServiceBrowserless or RenderServiceSplash.
+func RenderServiceAPI() string { return opts.env.RenderServiceAPI }
+
+// RenderServiceDomain returns true if web pages of hostname are always
+// rendered by the render service. Domains include their subdomains.
+func RenderServiceDomain(hostname string) bool {
+	if opts.env.RenderServiceURL == nil {
+		return false
+	}
+
+	hostname = strings.ToLower(hostname)
+	for _, domain := range opts.env.RenderServiceDomains {
+		domain = strings.ToLower(domain)
+		if hostname == domain || strings.HasSuffix(hostname, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
 func TrustedProxy(ip string) bool {
 	_, ok := opts.trustedProxies[ip]
 	return ok
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events delivers changes of entries and feeds to subscribed clients
+// of the same process.
+package events // import "miniflux.app/v2/internal/events"
+
+import (
+	"sync"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// bufferSize is the number of events, which a subscriber may lag behind,
+// before it's dropped.
+const bufferSize = 64
+
+// Broker publishes events of users to their subscribers. A nil Broker drops
+// all events.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[chan *model.Event]struct{}
+}
+
+// NewBroker returns a new Broker without subscribers.
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[int64]map[chan *model.Event]struct{})}
+}
+
+// Subscribe returns a channel of events of the user and the function to
+// unsubscribe. The channel is closed, when the subscriber is too slow to
+// receive events: it must reconnect and sync its state.
+func (self *Broker) Subscribe(userID int64,
+) (<-chan *model.Event, func()) {
+	ch := make(chan *model.Event, bufferSize)
+	self.mu.Lock()
+	subscribers, ok := self.subscribers[userID]
+	if !ok {
+		subscribers = make(map[chan *model.Event]struct{})
+		self.subscribers[userID] = subscribers
+	}
+	subscribers[ch] = struct{}{}
+	self.mu.Unlock()
+
+	return ch, func() {
+		self.mu.Lock()
+		self.unsubscribeLocked(userID, ch)
+		self.mu.Unlock()
+	}
+}
+
+func (self *Broker) unsubscribeLocked(userID int64, ch chan *model.Event) {
+	subscribers := self.subscribers[userID]
+	if _, ok := subscribers[ch]; !ok {
+		return
+	}
+
+	delete(subscribers, ch)
+	if len(subscribers) == 0 {
+		delete(self.subscribers, userID)
+	}
+	close(ch)
+}
+
+// Publish sends the event to all subscribers of the user without blocking.
+func (self *Broker) Publish(userID int64, event *model.Event) {
+	if self == nil {
+		return
+	}
+
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	for ch := range self.subscribers[userID] {
+		select {
+		case ch <- event:
+		default:
+			self.unsubscribeLocked(userID, ch)
+		}
+	}
+}
+
+// Subscribers returns the number of subscribers of the user.
+func (self *Broker) Subscribers(userID int64) int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return len(self.subscribers[userID])
+}
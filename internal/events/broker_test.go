@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package events // import "miniflux.app/v2/internal/events"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestBroker(t *testing.T) {
+	b := NewBroker()
+	ch1, unsubscribe1 := b.Subscribe(1)
+	ch2, unsubscribe2 := b.Subscribe(1)
+	other, unsubscribeOther := b.Subscribe(2)
+	defer unsubscribeOther()
+	assert.Equal(t, 2, b.Subscribers(1))
+
+	event := model.NewStatusEvent(model.EntryStatusRead, 42)
+	b.Publish(1, event)
+	assert.Same(t, event, <-ch1)
+	assert.Same(t, event, <-ch2)
+	assert.Empty(t, other)
+
+	unsubscribe1()
+	unsubscribe1()
+	_, ok := <-ch1
+	assert.False(t, ok)
+	assert.Equal(t, 1, b.Subscribers(1))
+
+	unsubscribe2()
+	assert.Zero(t, b.Subscribers(1))
+	b.Publish(1, event)
+}
+
+func TestBroker_slowSubscriber(t *testing.T) {
+	b := NewBroker()
+	ch, unsubscribe := b.Subscribe(1)
+	defer unsubscribe()
+
+	for range bufferSize + 1 {
+		b.Publish(1, &model.Event{Type: model.EventFeedCounters})
+	}
+	assert.Zero(t, b.Subscribers(1))
+
+	var received int
+	for range ch {
+		received++
+	}
+	assert.Equal(t, bufferSize, received)
+}
+
+func TestBroker_nil(t *testing.T) {
+	var b *Broker
+	require.NotPanics(t, func() {
+		b.Publish(1, &model.Event{Type: model.EventFeedCounters})
+	})
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package events // import "miniflux.app/v2/internal/events"
+
+import (
+	"context"
+	"time"
+
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+)
+
+// pingInterval is the interval of pings, which keep idle streams open
+// through proxies and detect clients, which went away.
+const pingInterval = 30 * time.Second
+
+// Stream calls send for every event of ch and pings the stream, until the
+// context is done, ch is closed or writing to the stream fails.
+func Stream(ctx context.Context, stream *response.EventStream,
+	ch <-chan *model.Event, send func(event *model.Event) error,
+) error {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-ch:
+			if !ok {
+				return nil
+			} else if err := send(event); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := stream.Ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StreamCoalesced is like Stream, but calls send once for all events of ch
+// matching match and received within delay after the first of them, so a
+// burst of changes is sent once.
+func StreamCoalesced(ctx context.Context, stream *response.EventStream,
+	ch <-chan *model.Event, delay time.Duration,
+	match func(event *model.Event) bool, send func() error,
+) error {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-ch:
+			if !ok {
+				return nil
+			} else if pending == nil && match(event) {
+				pending = time.After(delay)
+			}
+		case <-pending:
+			pending = nil
+			if err := send(); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := stream.Ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
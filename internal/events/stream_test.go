@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package events // import "miniflux.app/v2/internal/events"
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestStreamCoalesced(t *testing.T) {
+	stream, err := response.NewEventStream(httptest.NewRecorder())
+	require.NoError(t, err)
+
+	ch := make(chan *model.Event, bufferSize)
+	ch <- &model.Event{Type: model.EventEntriesStarred}
+	for range 3 {
+		ch <- model.NewStatusEvent(model.EntryStatusRead, 42)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var sent int
+	err = StreamCoalesced(ctx, stream, ch, 50*time.Millisecond,
+		func(e *model.Event) bool { return e.Type != model.EventEntriesStarred },
+		func() error {
+			sent++
+			cancel()
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestStreamCoalesced_noMatch(t *testing.T) {
+	stream, err := response.NewEventStream(httptest.NewRecorder())
+	require.NoError(t, err)
+
+	ch := make(chan *model.Event, 1)
+	ch <- &model.Event{Type: model.EventEntriesStarred}
+	close(ch)
+
+	err = StreamCoalesced(t.Context(), stream, ch, time.Millisecond,
+		func(e *model.Event) bool { return e.Type != model.EventEntriesStarred },
+		func() error {
+			t.Fatal("unexpected send")
+			return nil
+		})
+	require.NoError(t, err)
+}
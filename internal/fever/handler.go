@@ -433,14 +433,18 @@ func (h *handler) handleWriteItems(w http.ResponseWriter, r *http.Request,
 			model.EntryStatusUnread)
 	case "saved":
 		log.Debug("[Fever] Mark entry as saved")
-		err := h.store.ToggleBookmark(ctx, userID, entryID)
-		if err != nil {
-			return nil, response.WrapServerError(err)
+		if !entry.Starred {
+			err := h.store.SetEntriesBookmarkedState(ctx, userID,
+				[]int64{entryID}, true)
+			if err != nil {
+				return nil, response.WrapServerError(err)
+			}
 		}
 		integration.SendEntry(ctx, entry, request.User(r))
 	case "unsaved":
 		log.Debug("[Fever] Mark entry as unsaved")
-		err = h.store.ToggleBookmark(ctx, userID, entryID)
+		err = h.store.SetEntriesBookmarkedState(ctx, userID, []int64{entryID},
+			false)
 	}
 	if err != nil {
 		return nil, response.WrapServerError(err)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	mfs "miniflux.app/v2/internal/reader/subscription"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/template"
+	"miniflux.app/v2/internal/timezone"
 	"miniflux.app/v2/internal/urllib"
 	"miniflux.app/v2/internal/validator"
 )
@@ -48,6 +50,7 @@ var (
 	errEmptyFeedTitle   = errors.New("googlereader: empty feed title")
 	errFeedNotFound     = errors.New("googlereader: feed not found")
 	errCategoryNotFound = errors.New("googlereader: category not found")
+	errFolderLabel      = errors.New("googlereader: entries can't be tagged with a folder")
 	errSimultaneously   = fmt.Errorf("googlereader: %s and %s should not be supplied simultaneously", keptUnreadStreamSuffix, readStreamSuffix)
 )
 
@@ -291,12 +294,35 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	addLabels, addTags := splitLabelStreams(addTags)
+	removeLabels, removeTags := splitLabelStreams(removeTags)
 	tags, err := checkAndSimplifyTags(addTags, removeTags)
 	if err != nil {
 		response.ServerErrorJSON(w, r, err)
 		return
 	}
 
+	for _, title := range addLabels {
+		if slices.Contains(removeLabels, title) {
+			response.ServerErrorJSON(w, r, fmt.Errorf(
+				"googlereader: label %q should not be supplied for add and remove simultaneously",
+				title))
+			return
+		}
+
+		// Label streams of categories refer to folders, like in
+		// withLabelStream.
+		category, err := h.store.CategoryByTitle(ctx, user.ID, title)
+		if err != nil {
+			response.ServerErrorJSON(w, r, err)
+			return
+		} else if category != nil {
+			response.BadRequestJSON(w, r,
+				fmt.Errorf("%w: %q", errFolderLabel, title))
+			return
+		}
+	}
+
 	itemIDs, err := parseItemIDsFromRequest(r)
 	if err != nil {
 		response.BadRequestJSON(w, r, err)
@@ -305,7 +331,9 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Debug("[GoogleReader] Edited tags",
 		slog.Any("item_ids", itemIDs),
-		slog.Any("tags", tags))
+		slog.Any("tags", tags),
+		slog.Any("add_labels", addLabels),
+		slog.Any("remove_labels", removeLabels))
 
 	entries, err := h.store.NewEntryQueryBuilder(user.ID).
 		WithEntryIDs(itemIDs).
@@ -317,11 +345,13 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var n int
+	entryIDs := make([]int64, len(entries))
 	readEntryIDs := make([]int64, 0)
 	unreadEntryIDs := make([]int64, 0)
 	starredEntryIDs := make([]int64, 0)
 	unstarredEntryIDs := make([]int64, 0)
-	for _, entry := range entries {
+	for i, entry := range entries {
+		entryIDs[i] = entry.ID
 		if markRead, exists := tags[ReadStream]; exists {
 			switch entry.Status {
 			case model.EntryStatusUnread:
@@ -381,6 +411,13 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	if len(entryIDs) > 0 && len(addLabels)+len(removeLabels) > 0 {
+		g.Go(func() error {
+			return h.editEntryLabels(ctx, user.ID, entryIDs, addLabels,
+				removeLabels)
+		})
+	}
+
 	if err := g.Wait(); err != nil {
 		response.ServerErrorJSON(w, r, err)
 		return
@@ -392,6 +429,64 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 	response.Text(w, r, "OK")
 }
 
+// splitLabelStreams separates label streams, which assign user labels to
+// entries, from state streams.
+func splitLabelStreams(streams []Stream) ([]string, []Stream) {
+	var labels []string
+	others := make([]Stream, 0, len(streams))
+	for _, s := range streams {
+		if s.Type == LabelStream {
+			labels = append(labels, s.ID)
+		} else {
+			others = append(others, s)
+		}
+	}
+	return labels, others
+}
+
+// editEntryLabels adds and removes labels of entries. Missing labels are
+// created on add and ignored on remove.
+func (h *handler) editEntryLabels(ctx context.Context, userID int64,
+	entryIDs []int64, addLabels, removeLabels []string,
+) error {
+	for _, title := range addLabels {
+		label, err := h.store.LabelByTitle(ctx, userID, title)
+		if err != nil {
+			return err
+		} else if label == nil {
+			labelRequest := model.LabelRequest{Title: title}
+			lerr := validator.ValidateLabel(ctx, h.store, userID, 0, &labelRequest)
+			if lerr != nil {
+				return lerr.Error()
+			}
+			label, err = h.store.CreateLabel(ctx, userID, labelRequest.Title)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = h.store.AddEntriesLabel(ctx, userID, label.ID, entryIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	labelIDs := make([]int64, 0, len(removeLabels))
+	for _, title := range removeLabels {
+		label, err := h.store.LabelByTitle(ctx, userID, title)
+		if err != nil {
+			return err
+		} else if label != nil {
+			labelIDs = append(labelIDs, label.ID)
+		}
+	}
+
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	return h.store.RemoveEntriesLabels(ctx, userID, entryIDs, labelIDs...)
+}
+
 func (h *handler) quickAddHandler(w http.ResponseWriter, r *http.Request,
 ) (*quickAddResponse, error) {
 	user := request.User(r)
@@ -737,6 +832,16 @@ func (h *handler) streamItemContentsHandler(w http.ResponseWriter,
 	userRead := streamPrefix + readStreamSuffix
 	userStarred := streamPrefix + starredStreamSuffix
 
+	entryIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		entryIDs[i] = entry.ID
+	}
+
+	entryLabels, err := h.store.EntryLabels(ctx, user.ID, entryIDs)
+	if err != nil {
+		return nil, response.WrapServerError(err)
+	}
+
 	labelPrefix := fmt.Sprintf(userLabelPrefix, user.ID)
 	items := make([]contentItem, len(entries))
 	for i, entry := range entries {
@@ -753,6 +858,11 @@ func (h *handler) streamItemContentsHandler(w http.ResponseWriter,
 		if entry.Feed.Category.Title != "" {
 			categories = append(categories, labelPrefix+entry.Feed.Category.Title)
 		}
+		for _, label := range entryLabels[entry.ID] {
+			if label.Title != entry.Feed.Category.Title {
+				categories = append(categories, labelPrefix+label.Title)
+			}
+		}
 		if entry.Status == model.EntryStatusRead {
 			categories = append(categories, userRead)
 		}
@@ -925,7 +1035,18 @@ func (h *handler) tagListHandler(w http.ResponseWriter, r *http.Request,
 		return nil, response.WrapServerError(err)
 	}
 
-	result.Tags = make([]subscriptionCategoryResponse, 0, len(categories)+1)
+	labels, err := h.store.Labels(ctx, userID)
+	if err != nil {
+		return nil, response.WrapServerError(err)
+	}
+
+	searches, err := h.store.SavedSearches(ctx, userID)
+	if err != nil {
+		return nil, response.WrapServerError(err)
+	}
+
+	result.Tags = make([]subscriptionCategoryResponse, 0,
+		len(categories)+len(labels)+len(searches)+1)
 	result.Tags = append(result.Tags, subscriptionCategoryResponse{
 		ID: fmt.Sprintf(userStreamPrefix, userID) + starredStreamSuffix,
 	})
@@ -938,6 +1059,37 @@ func (h *handler) tagListHandler(w http.ResponseWriter, r *http.Request,
 			Type:  "folder",
 		})
 	}
+
+	// Categories, labels and saved searches share the same stream IDs, the
+	// first listed wins.
+	for _, label := range labels {
+		folder := slices.ContainsFunc(categories, func(c model.Category) bool {
+			return c.Title == label.Title
+		})
+		if folder {
+			continue
+		}
+		result.Tags = append(result.Tags, subscriptionCategoryResponse{
+			ID:    labelPrefix + label.Title,
+			Label: label.Title,
+			Type:  "tag",
+		})
+	}
+
+	for _, search := range searches {
+		used := slices.ContainsFunc(result.Tags,
+			func(t subscriptionCategoryResponse) bool {
+				return t.Label == search.Title
+			})
+		if used {
+			continue
+		}
+		result.Tags = append(result.Tags, subscriptionCategoryResponse{
+			ID:    labelPrefix + search.Title,
+			Label: search.Title,
+			Type:  "tag",
+		})
+	}
 	return result, nil
 }
 
@@ -1054,6 +1206,8 @@ func (h *handler) streamItemIDsHandler(w http.ResponseWriter, r *http.Request,
 		return h.handleReadStreamHandler(r, modifiers)
 	case FeedStream:
 		return h.handleFeedStreamHandler(r, modifiers)
+	case LabelStream:
+		return h.handleLabelStreamHandler(r, modifiers)
 	default:
 	}
 
@@ -1188,6 +1342,75 @@ func (h *handler) handleFeedStreamHandler(r *http.Request, rm RequestModifiers,
 	return &streamId, nil
 }
 
+// handleLabelStreamHandler returns entries of the category, the user label or
+// the saved search with the title of the stream, in this order.
+func (h *handler) handleLabelStreamHandler(r *http.Request,
+	rm RequestModifiers,
+) (*streamIDResponse, error) {
+	ctx := r.Context()
+	builder := h.store.NewEntryQueryBuilder(rm.UserID).
+		WithoutStatus(model.EntryStatusRemoved)
+
+	found, err := h.withLabelStream(r, builder, rm.Streams[0].ID)
+	if err != nil {
+		return nil, response.WrapServerError(err)
+	} else if !found {
+		return &streamIDResponse{ItemRefs: []itemRef{}}, nil
+	}
+
+	for _, s := range rm.ExcludeTargets {
+		if s.Type == ReadStream {
+			builder.WithoutStatus(model.EntryStatusRead)
+			break
+		}
+	}
+
+	streamId, err := makeStreamIDResp(ctx, builder, &rm)
+	if err != nil {
+		return nil, response.WrapServerError(err)
+	}
+	return &streamId, nil
+}
+
+// withLabelStream filters the builder by the category, the label or the saved
+// search with the given title, in this order, like tagListHandler lists them.
+// It returns false when none exists.
+func (h *handler) withLabelStream(r *http.Request, b *storage.EntryQueryBuilder,
+	title string,
+) (bool, error) {
+	ctx := r.Context()
+	user := request.User(r)
+	category, err := h.store.CategoryByTitle(ctx, user.ID, title)
+	if err != nil {
+		return false, err
+	} else if category != nil {
+		b.WithCategoryID(category.ID)
+		return true, nil
+	}
+
+	label, err := h.store.LabelByTitle(ctx, user.ID, title)
+	if err != nil {
+		return false, err
+	} else if label != nil {
+		b.WithLabelID(label.ID)
+		return true, nil
+	}
+
+	search, err := h.store.SavedSearchByTitle(ctx, user.ID, title)
+	if err != nil {
+		return false, err
+	} else if search == nil {
+		return false, nil
+	}
+
+	q, err := search.SearchQuery(timezone.Location(user.Timezone))
+	if err != nil {
+		return false, err
+	}
+	b.WithSearch(q)
+	return true, nil
+}
+
 func (h *handler) markAllAsReadHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logging.FromContext(ctx).Debug("[GoogleReader] Handle /mark-all-as-read",
@@ -1246,23 +1469,57 @@ func (h *handler) markAllAsReadHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	case LabelStream:
+		// Same order as withLabelStream: categories, labels and saved searches.
 		category, err := h.store.CategoryByTitle(ctx, userID, stream.ID)
 		if err != nil {
 			response.ServerErrorJSON(w, r, err)
 			return
-		} else if category == nil {
-			response.NotFoundJSON(w, r)
+		} else if category != nil {
+			affected, err := h.store.MarkCategoryAsRead(ctx, userID, category.ID,
+				before)
+			if err != nil {
+				response.ServerErrorJSON(w, r, err)
+				return
+			} else if !affected {
+				response.NotFoundJSON(w, r)
+				return
+			}
+			break
+		}
+
+		label, err := h.store.LabelByTitle(ctx, userID, stream.ID)
+		if err != nil {
+			response.ServerErrorJSON(w, r, err)
 			return
+		} else if label != nil {
+			err := h.store.MarkLabelAsRead(ctx, userID, label.ID, before)
+			if err != nil {
+				response.ServerErrorJSON(w, r, err)
+				return
+			}
+			break
 		}
-		affected, err := h.store.MarkCategoryAsRead(ctx, userID, category.ID,
-			before)
+
+		search, err := h.store.SavedSearchByTitle(ctx, userID, stream.ID)
 		if err != nil {
 			response.ServerErrorJSON(w, r, err)
 			return
-		} else if !affected {
+		} else if search == nil {
 			response.NotFoundJSON(w, r)
 			return
 		}
+
+		q, err := search.SearchQuery(
+			timezone.Location(request.User(r).Timezone))
+		if err != nil {
+			response.ServerErrorJSON(w, r, err)
+			return
+		}
+		err = h.store.MarkSavedSearchAsRead(ctx, userID, q, before)
+		if err != nil {
+			response.ServerErrorJSON(w, r, err)
+			return
+		}
 	case ReadingListStream:
 		err := h.store.MarkAllAsReadBeforeDate(ctx, userID, before)
 		if err != nil {
@@ -59,3 +59,20 @@ func Test_getStreamFilterModifiers_SortDirection(t *testing.T) {
 		})
 	}
 }
+
+func Test_splitLabelStreams(t *testing.T) {
+	streams, err := getStreams([]string{
+		"user/-/label/Triage",
+		"user/-/state/com.google/starred",
+		"user/1/label/Later",
+	}, 1)
+	require.NoError(t, err)
+
+	labels, others := splitLabelStreams(streams)
+	assert.Equal(t, []string{"Triage", "Later"}, labels)
+	assert.Equal(t, []Stream{{Type: StarredStream}}, others)
+
+	tags, err := checkAndSimplifyTags(others, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[StreamType]bool{StarredStream: true}, tags)
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package response // import "miniflux.app/v2/internal/http/response"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const textEventStream = "text/event-stream"
+
+// EventStream writes Server-Sent Events to the client.
+type EventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewEventStream starts the stream of events. The write timeout of the server
+// doesn't apply to the stream, callers should send pings to detect clients,
+// which went away.
+func NewEventStream(w http.ResponseWriter) (*EventStream, error) {
+	rc := http.NewResponseController(w)
+	err := rc.SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, fmt.Errorf("response: disable write deadline: %w", err)
+	}
+
+	h := w.Header()
+	h.Set(contentType, textEventStream)
+	h.Set(cacheControl, "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	self := &EventStream{w: w, rc: rc}
+	if err := self.flush(); err != nil {
+		return nil, err
+	}
+	return self, nil
+}
+
+// Send writes the event with the given name and v encoded as JSON data.
+func (self *EventStream) Send(name string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("response: marshal event %q: %w", name, err)
+	}
+
+	_, err = fmt.Fprintf(self.w, "event: %s\ndata: %s\n\n", name, b)
+	if err != nil {
+		return fmt.Errorf("response: write event %q: %w", name, err)
+	}
+	return self.flush()
+}
+
+// Ping writes a comment, which is ignored by clients.
+func (self *EventStream) Ping() error {
+	if _, err := fmt.Fprint(self.w, ": ping\n\n"); err != nil {
+		return fmt.Errorf("response: write ping: %w", err)
+	}
+	return self.flush()
+}
+
+func (self *EventStream) flush() error {
+	if err := self.rc.Flush(); err != nil {
+		return fmt.Errorf("response: flush event stream: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package response
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	stream, err := NewEventStream(w)
+	require.NoError(t, err)
+	assert.True(t, w.Flushed)
+	assert.Equal(t, textEventStream, w.Header().Get(contentType))
+
+	require.NoError(t, stream.Send("entries_status",
+		map[string]any{"entry_ids": []int{1, 2}}))
+	require.NoError(t, stream.Ping())
+	assert.Equal(t,
+		"event: entries_status\ndata: {\"entry_ids\":[1,2]}\n\n: ping\n\n",
+		w.Body.String())
+}
@@ -59,12 +59,32 @@ var (
 		},
 		[]string{"status"},
 	)
+
+	ReclaimedEntries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "reclaimed_entries_total",
+			Help:      "Number of removed entries compacted by the cleanup job or deleted by feed refreshes",
+		},
+		[]string{"action"},
+	)
+
+	FilterRuleHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "filter_rule_hits_total",
+			Help:      "Number of entries matched by block and keep filter rules",
+		},
+		[]string{"action"},
+	)
 )
 
 func RegisterMetrics(store *storage.Storage) {
 	prometheus.MustRegister(BackgroundFeedRefreshDuration)
 	prometheus.MustRegister(ScraperRequestDuration)
 	prometheus.MustRegister(ArchiveEntriesDuration)
+	prometheus.MustRegister(FilterRuleHits)
+	prometheus.MustRegister(ReclaimedEntries)
 	store.RegisterMetricts()
 }
 
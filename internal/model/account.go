@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// AccountArchiveVersion is the version of the account archive format.
+const AccountArchiveVersion = 1
+
+// AccountArchive is the full export of a user account, used to move it to
+// another instance.
+type AccountArchive struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Settings of the user, including filter rules and integrations. The
+	// identity of the user isn't restored.
+	Settings *User `json:"settings"`
+
+	Categories    []*Category          `json:"categories"`
+	Feeds         []*Feed              `json:"feeds"`
+	Labels        []string             `json:"labels,omitempty"`
+	SavedSearches []SavedSearchRequest `json:"saved_searches,omitempty"`
+
+	// APIKeys are descriptions of API keys. New keys are created on restore.
+	APIKeys []string `json:"api_keys,omitempty"`
+
+	// Entries are unread, starred and labeled entries.
+	Entries []*ArchivedEntry `json:"entries"`
+}
+
+// ArchivedEntry is an entry of the account archive with the URL of its feed
+// and titles of its labels.
+type ArchivedEntry struct {
+	*Entry
+
+	FeedURL string   `json:"feed_url"`
+	Labels  []string `json:"labels,omitempty"`
+}
+
+// AccountRestoreResult reports what has been restored from an account archive.
+// Existing categories, feeds, labels and saved searches are kept.
+type AccountRestoreResult struct {
+	Categories    int `json:"categories"`
+	Feeds         int `json:"feeds"`
+	Labels        int `json:"labels"`
+	SavedSearches int `json:"saved_searches"`
+	APIKeys       int `json:"api_keys"`
+	Entries       int `json:"entries"`
+}
@@ -19,8 +19,9 @@ type Category struct {
 }
 
 type CategoryExtra struct {
-	BlockFilter string `json:"block_filter,omitempty"`
-	HideLabel   bool   `json:"hide_label,omitempty"`
+	BlockFilter string    `json:"block_filter,omitempty"`
+	HideLabel   bool      `json:"hide_label,omitempty"`
+	Retention   Retention `json:"retention,omitzero"`
 }
 
 func (self *Category) String() string {
@@ -38,10 +39,11 @@ type CategoryCreationRequest struct {
 }
 
 type CategoryModificationRequest struct {
-	Title        *string `json:"title,omitzero"`
-	HideGlobally *bool   `json:"hide_globally,omitzero"`
-	HideLabel    *bool   `json:"hide_label,omitzero"`
-	BlockFilter  *string `json:"block_filter,omitempty"`
+	Title        *string    `json:"title,omitzero"`
+	HideGlobally *bool      `json:"hide_globally,omitzero"`
+	HideLabel    *bool      `json:"hide_label,omitzero"`
+	BlockFilter  *string    `json:"block_filter,omitempty"`
+	Retention    *Retention `json:"retention,omitempty"`
 }
 
 func (self *CategoryModificationRequest) Patch(category *Category) {
@@ -60,4 +62,8 @@ func (self *CategoryModificationRequest) Patch(category *Category) {
 	if self.BlockFilter != nil {
 		category.Extra.BlockFilter = *self.BlockFilter
 	}
+
+	if self.Retention != nil {
+		category.Extra.Retention = *self.Retention
+	}
 }
@@ -18,6 +18,8 @@ type Enclosure struct {
 	MediaProgression int64  `json:"media_progression,omitempty"`
 	Height           int    `json:"height,omitempty"`
 	Width            int    `json:"width,omitempty"`
+	// Duration is how long it takes to play the enclosure, in seconds.
+	Duration int64 `json:"duration,omitempty"`
 
 	originalURL string
 	parsedURL   *url.URL
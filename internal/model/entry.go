@@ -48,6 +48,10 @@ type Entry struct {
 	Tags        []string   `json:"tags" db:"tags"`
 	Extra       EntryExtra `json:"extra,omitzero" db:"extra"`
 
+	// Snippet is an HTML excerpt of the content matching the full-text search
+	// query, with matches in <mark> elements.
+	Snippet string `json:"snippet,omitempty" db:"-"`
+
 	parsedURL *url.URL
 	atom      *atom.Entry
 	imported  bool
@@ -256,6 +260,13 @@ func NewEntryFrom(ext *ExternalEntry) *Entry {
 	return entry
 }
 
+// WithImportedState sets the status and the starred flag of an imported entry,
+// which are kept by the processing of stored entries.
+func (self *Entry) WithImportedState(status string, starred bool) *Entry {
+	self.Status, self.Starred, self.imported = status, starred, true
+	return self
+}
+
 type ImportEntries struct {
 	FeedURL string           `json:"feed_url,omitzero"`
 	Entries []*ExternalEntry `json:"entries,omitzero"`
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// Types of events of the event stream.
+const (
+	EventEntriesCreated = "entries_created"
+	EventEntriesStatus  = "entries_status"
+	EventEntriesStarred = "entries_starred"
+	EventEntriesRemoved = "entries_removed"
+	EventFeedCounters   = "feed_counters"
+)
+
+// Event is a change of entries or feed counters of a user, streamed to
+// clients. Events without EntryIDs are about all entries of the feed or of
+// the user, if FeedID is zero too.
+type Event struct {
+	Type     string  `json:"type"`
+	FeedID   int64   `json:"feed_id,omitempty"`
+	EntryIDs []int64 `json:"entry_ids,omitempty"`
+	Status   string  `json:"status,omitempty"`
+	Starred  *bool   `json:"starred,omitempty"`
+}
+
+// NewStatusEvent returns the event of the status change of entries.
+func NewStatusEvent(status string, entryIDs ...int64) *Event {
+	if status == EntryStatusRemoved {
+		return &Event{Type: EventEntriesRemoved, EntryIDs: entryIDs}
+	}
+	return &Event{Type: EventEntriesStatus, Status: status, EntryIDs: entryIDs}
+}
+
+// WithFeedID sets the feed of the event.
+func (self *Event) WithFeedID(feedID int64) *Event {
+	self.FeedID = feedID
+	return self
+}
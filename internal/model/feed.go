@@ -79,6 +79,7 @@ type Feed struct {
 	filteredByRules  int
 	filteredByHash   int
 	filteredByStored int
+	filterHits       map[FilterRuleKey]int
 
 	feedURL *url.URL
 	siteURL *url.URL
@@ -91,9 +92,13 @@ type FeedExtra struct {
 	BlockMarkRead       bool     `json:"blockMarkRead,omitempty"`
 	CommentsURLTemplate string   `json:"comments_url_template,omitempty"`
 	IgnoreEntryUpdates  bool     `json:"ignore_entry_updates,omitempty"`
+	RefreshInterval     int      `json:"refresh_interval,omitempty"`
+	RenderPages         bool     `json:"render_pages,omitempty"`
 
 	BlockFilterEntryRules string `json:"block_filter_entry_rules,omitempty"`
 	KeepFilterEntryRules  string `json:"keep_filter_entry_rules,omitempty"`
+
+	Retention Retention `json:"retention,omitzero"`
 }
 
 type FeedRuntime struct {
@@ -102,6 +107,16 @@ type FeedRuntime struct {
 
 	Language  string     `json:"language,omitempty"`
 	BadStatus *BadStatus `json:"badStatus,omitzero"`
+
+	// CheckInterval is the polling interval in minutes, calculated by the entry
+	// frequency scheduler.
+	CheckInterval int `json:"checkInterval,omitempty"`
+
+	// Polling hints declared by the publisher: hours (0-23 GMT) and days of week,
+	// when the feed shouldn't be polled, and declared update period in minutes.
+	SkipHours    []int          `json:"skipHours,omitempty"`
+	SkipDays     []time.Weekday `json:"skipDays,omitempty"`
+	UpdatePeriod int            `json:"updatePeriod,omitempty"`
 }
 
 type BadStatus struct {
@@ -230,15 +245,144 @@ func (self *Feed) ScheduleNextCheck(refreshDelayInMinutes int) int {
 	//
 	// Use the RSS TTL field, Retry-After, Cache-Control or Expires HTTP headers
 	// if defined.
-	intervalMinutes := max(config.SchedulerRoundRobinMinInterval(),
-		refreshDelayInMinutes)
+	minInterval := config.SchedulerRoundRobinMinInterval()
+	maxInterval := config.SchedulerRoundRobinMaxInterval()
+
+	// The refresh interval configured for this feed has priority over global
+	// defaults. The refresh delay still wins, if it's greater, like for
+	// Retry-After.
+	if self.RefreshInterval() > 0 {
+		return self.scheduleNextCheckIn(
+			max(self.RefreshInterval(), refreshDelayInMinutes))
+	}
+
+	// The update period declared by the publisher works like TTL.
+	refreshDelayInMinutes = max(self.Runtime.UpdatePeriod, refreshDelayInMinutes)
+
+	// The entry frequency scheduler uses the interval calculated from recently
+	// published entries, unless it wasn't calculated yet.
+	if self.entryFrequencyScheduler() {
+		minInterval = config.SchedulerEntryFrequencyMinInterval()
+		maxInterval = config.SchedulerEntryFrequencyMaxInterval()
+		refreshDelayInMinutes = max(self.Runtime.CheckInterval,
+			refreshDelayInMinutes)
+	}
+
+	intervalMinutes := max(minInterval, refreshDelayInMinutes)
 
 	// Limit the max interval value for misconfigured feeds.
-	intervalMinutes = min(config.SchedulerRoundRobinMaxInterval(),
-		intervalMinutes)
+	intervalMinutes = min(maxInterval, intervalMinutes)
+	return self.scheduleNextCheckIn(intervalMinutes)
+}
+
+// scheduleNextCheckIn sets "next_check_at" after given minutes, moving it out
+// of hours and days, when the publisher asked us to skip polling.
+func (self *Feed) scheduleNextCheckIn(minutes int) int {
+	nextCheck := time.Now().Add(time.Minute * time.Duration(minutes))
+	if d := self.skipDuration(nextCheck); d > 0 {
+		nextCheck = nextCheck.Add(d)
+		minutes += int(d.Minutes())
+	}
+	self.NextCheckAt = nextCheck
+	return minutes
+}
 
-	self.NextCheckAt = time.Now().Add(time.Minute * time.Duration(intervalMinutes))
-	return intervalMinutes
+// skipDuration returns how long to wait after t, until the beginning of the
+// first hour, allowed by skipHours and skipDays.
+func (self *Feed) skipDuration(t time.Time) time.Duration {
+	skipHours, skipDays := self.Runtime.SkipHours, self.Runtime.SkipDays
+	if len(skipHours) == 0 && len(skipDays) == 0 {
+		return 0
+	}
+
+	t = t.UTC()
+	// Check every hour of the week and give up, if all of them are forbidden.
+	for next := t; next.Sub(t) <= 7*24*time.Hour; {
+		if !slices.Contains(skipHours, next.Hour()) &&
+			!slices.Contains(skipDays, next.Weekday()) {
+			return next.Sub(t)
+		}
+		next = next.Truncate(time.Hour).Add(time.Hour)
+	}
+	return 0
+}
+
+func (self *Feed) entryFrequencyScheduler() bool {
+	return config.PollingScheduler() == config.SchedulerEntryFrequency &&
+		self.Runtime.CheckInterval > 0
+}
+
+// WithWeeklyEntryCount calculates polling interval of the entry frequency
+// scheduler, using the number of entries published during the last week.
+func (self *Feed) WithWeeklyEntryCount(weeklyCount int) *Feed {
+	maxInterval := config.SchedulerEntryFrequencyMaxInterval()
+	if weeklyCount <= 0 {
+		self.Runtime.CheckInterval = maxInterval
+		return self
+	}
+
+	const minutesPerWeek = 7 * 24 * 60
+	interval := minutesPerWeek /
+		(weeklyCount * config.SchedulerEntryFrequencyFactor())
+	self.Runtime.CheckInterval = min(maxInterval,
+		max(config.SchedulerEntryFrequencyMinInterval(), interval))
+	return self
+}
+
+func (self *Feed) CheckInterval() int { return self.Runtime.CheckInterval }
+
+// WithSkipHours sets hours (0-23 GMT), when the feed shouldn't be polled.
+func (self *Feed) WithSkipHours(hours []int) *Feed {
+	hours = slices.DeleteFunc(hours, func(h int) bool { return h < 0 || h > 23 })
+	if len(hours) == 0 {
+		self.Runtime.SkipHours = nil
+		return self
+	}
+
+	slices.Sort(hours)
+	self.Runtime.SkipHours = slices.Compact(hours)
+	return self
+}
+
+func (self *Feed) SkipHours() []int { return self.Runtime.SkipHours }
+
+// WithSkipDays sets days of week, when the feed shouldn't be polled.
+func (self *Feed) WithSkipDays(days []time.Weekday) *Feed {
+	if len(days) == 0 {
+		self.Runtime.SkipDays = nil
+		return self
+	}
+
+	slices.Sort(days)
+	self.Runtime.SkipDays = slices.Compact(days)
+	return self
+}
+
+func (self *Feed) SkipDays() []time.Weekday { return self.Runtime.SkipDays }
+
+// WithUpdatePeriod sets update period in minutes, declared by the publisher.
+func (self *Feed) WithUpdatePeriod(minutes int) *Feed {
+	self.Runtime.UpdatePeriod = max(0, minutes)
+	return self
+}
+
+func (self *Feed) UpdatePeriod() int { return self.Runtime.UpdatePeriod }
+
+// WithPollingHints copies polling hints declared by the publisher of remote
+// feed.
+func (self *Feed) WithPollingHints(remote *Feed) *Feed {
+	return self.WithSkipHours(remote.SkipHours()).
+		WithSkipDays(remote.SkipDays()).
+		WithUpdatePeriod(remote.UpdatePeriod())
+}
+
+// RefreshInterval returns refresh interval in minutes, configured for this
+// feed, or 0 if it follows global defaults.
+func (self *Feed) RefreshInterval() int { return self.Extra.RefreshInterval }
+
+func (self *Feed) WithRefreshInterval(minutes int) *Feed {
+	self.Extra.RefreshInterval = max(0, minutes)
+	return self
 }
 
 func (self *Feed) Size() uint64 { return self.Runtime.Size }
@@ -326,6 +470,16 @@ func (self *Feed) FilteredByHash() int { return self.filteredByHash }
 func (self *Feed) IncFilteredByStored()  { self.filteredByStored++ }
 func (self *Feed) FilteredByStored() int { return self.filteredByStored }
 
+// IncFilterHit counts an entry matched by a block or keep rule.
+func (self *Feed) IncFilterHit(action FilterAction, rule string) {
+	if self.filterHits == nil {
+		self.filterHits = make(map[FilterRuleKey]int)
+	}
+	self.filterHits[FilterRuleKey{Action: action, Rule: rule}]++
+}
+
+func (self *Feed) FilterHits() map[FilterRuleKey]int { return self.filterHits }
+
 func (self *Feed) WithIgnoreEntryUpdates(v bool) *Feed {
 	self.Extra.IgnoreEntryUpdates = v
 	return self
@@ -335,6 +489,17 @@ func (self *Feed) IgnoreEntryUpdates() bool {
 	return self.Extra.IgnoreEntryUpdates
 }
 
+func (self *Feed) WithRenderPages(v bool) *Feed {
+	self.Extra.RenderPages = v
+	return self
+}
+
+// RenderPages returns true if web pages of entries are scraped through the
+// render service.
+func (self *Feed) RenderPages() bool {
+	return self.Extra.RenderPages
+}
+
 func (self *Feed) WithBadStatus(content, contentType string) *Feed {
 	if content == "" {
 		self.Runtime.BadStatus = nil
@@ -385,6 +550,7 @@ type FeedCreationRequest struct {
 	KeepFilterEntryRules        string   `json:"keep_filter_entry_rules"`
 	UrlRewriteRules             string   `json:"urlrewrite_rules"`
 	ProxyURL                    string   `json:"proxy_url"`
+	RefreshInterval             int      `json:"refresh_interval,omitempty"`
 }
 
 type FeedCreationRequestFromSubscriptionDiscovery struct {
@@ -397,32 +563,47 @@ type FeedCreationRequestFromSubscriptionDiscovery struct {
 
 // FeedModificationRequest represents the request to update a feed.
 type FeedModificationRequest struct {
-	FeedURL                     *string   `json:"feed_url"`
-	SiteURL                     *string   `json:"site_url"`
-	Title                       *string   `json:"title"`
-	Description                 *string   `json:"description"`
-	ScraperRules                *string   `json:"scraper_rules"`
-	RewriteRules                *string   `json:"rewrite_rules"`
-	UrlRewriteRules             *string   `json:"urlrewrite_rules"`
-	BlockAuthors                *[]string `json:"blockAuthors,omitempty"`
-	BlockFilterEntryRules       *string   `json:"block_filter_entry_rules"`
-	KeepFilterEntryRules        *string   `json:"keep_filter_entry_rules"`
-	Crawler                     *bool     `json:"crawler"`
-	IgnoreEntryUpdates          *bool     `json:"ignore_entry_updates,omitempty"`
-	UserAgent                   *string   `json:"user_agent"`
-	Cookie                      *string   `json:"cookie"`
-	Username                    *string   `json:"username"`
-	Password                    *string   `json:"password"`
-	CategoryID                  *int64    `json:"category_id"`
-	Disabled                    *bool     `json:"disabled"`
-	NoMediaPlayer               *bool     `json:"no_media_player"`
-	IgnoreHTTPCache             *bool     `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates *bool     `json:"allow_self_signed_certificates"`
-	FetchViaProxy               *bool     `json:"fetch_via_proxy"`
-	HideGlobally                *bool     `json:"hide_globally"`
-	DisableHTTP2                *bool     `json:"disable_http2"`
-	ProxyURL                    *string   `json:"proxy_url"`
-	CommentsURLTemplate         *string   `json:"comments_url_template,omitempty"`
+	FeedURL                     *string    `json:"feed_url"`
+	SiteURL                     *string    `json:"site_url"`
+	Title                       *string    `json:"title"`
+	Description                 *string    `json:"description"`
+	ScraperRules                *string    `json:"scraper_rules"`
+	RewriteRules                *string    `json:"rewrite_rules"`
+	UrlRewriteRules             *string    `json:"urlrewrite_rules"`
+	BlockAuthors                *[]string  `json:"blockAuthors,omitempty"`
+	BlockFilterEntryRules       *string    `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules        *string    `json:"keep_filter_entry_rules"`
+	Crawler                     *bool      `json:"crawler"`
+	IgnoreEntryUpdates          *bool      `json:"ignore_entry_updates,omitempty"`
+	UserAgent                   *string    `json:"user_agent"`
+	Cookie                      *string    `json:"cookie"`
+	Username                    *string    `json:"username"`
+	Password                    *string    `json:"password"`
+	CategoryID                  *int64     `json:"category_id"`
+	Disabled                    *bool      `json:"disabled"`
+	NoMediaPlayer               *bool      `json:"no_media_player"`
+	IgnoreHTTPCache             *bool      `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates *bool      `json:"allow_self_signed_certificates"`
+	FetchViaProxy               *bool      `json:"fetch_via_proxy"`
+	HideGlobally                *bool      `json:"hide_globally"`
+	DisableHTTP2                *bool      `json:"disable_http2"`
+	ProxyURL                    *string    `json:"proxy_url"`
+	CommentsURLTemplate         *string    `json:"comments_url_template,omitempty"`
+	RefreshInterval             *int       `json:"refresh_interval,omitempty"`
+	RenderPages                 *bool      `json:"render_pages,omitempty"`
+	Retention                   *Retention `json:"retention,omitempty"`
+}
+
+// FeedsModificationRequest represents the request to apply the same changes
+// to several feeds.
+type FeedsModificationRequest struct {
+	FeedIDs []int64                 `json:"feed_ids"`
+	Changes FeedModificationRequest `json:"changes"`
+}
+
+// FeedsRemovalRequest represents the request to remove several feeds.
+type FeedsRemovalRequest struct {
+	FeedIDs []int64 `json:"feed_ids"`
 }
 
 // Patch updates a feed with modified values.
@@ -530,6 +711,18 @@ func (self *FeedModificationRequest) Patch(feed *Feed) {
 	if self.CommentsURLTemplate != nil {
 		feed.Extra.CommentsURLTemplate = *self.CommentsURLTemplate
 	}
+
+	if self.RefreshInterval != nil {
+		feed.WithRefreshInterval(*self.RefreshInterval)
+	}
+
+	if self.RenderPages != nil {
+		feed.WithRenderPages(*self.RenderPages)
+	}
+
+	if self.Retention != nil {
+		feed.Extra.Retention = *self.Retention
+	}
 }
 
 // Feeds is a list of feed
@@ -9,6 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"miniflux.app/v2/internal/config"
 )
 
@@ -178,3 +181,139 @@ func TestFeedScheduleNextCheckRoundRobinMinInterval(t *testing.T) {
 	expectedInterval := minInterval
 	checkTargetInterval(t, feed, expectedInterval, timeBefore, "TestFeedScheduleNextCheckRoundRobinMinInterval")
 }
+
+func TestFeedScheduleNextCheckEntryFrequency(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	require.NoError(t, config.Load(""))
+
+	tests := []struct {
+		name        string
+		weeklyCount int
+		expected    int
+	}{
+		{
+			name:     "no entries",
+			expected: config.SchedulerEntryFrequencyMaxInterval(),
+		},
+		{
+			name:        "daily entry",
+			weeklyCount: 7,
+			expected:    24 * 60,
+		},
+		{
+			name:        "hourly entries",
+			weeklyCount: 7 * 24,
+			expected:    60,
+		},
+		{
+			name:        "too many entries",
+			weeklyCount: 7 * 24 * 60,
+			expected:    config.SchedulerEntryFrequencyMinInterval(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeBefore := time.Now()
+			feed := new(Feed).WithWeeklyEntryCount(tt.weeklyCount)
+			assert.Equal(t, tt.expected, feed.CheckInterval())
+			assert.Equal(t, tt.expected, feed.ScheduleNextCheck(noRefreshDelay))
+			checkTargetInterval(t, feed, tt.expected, timeBefore, tt.name)
+		})
+	}
+}
+
+func TestFeedScheduleNextCheckEntryFrequencyWithRefreshDelay(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	require.NoError(t, config.Load(""))
+
+	feed := new(Feed).WithWeeklyEntryCount(7 * 24)
+	assert.Equal(t, 120, feed.ScheduleNextCheck(120))
+	assert.Equal(t, config.SchedulerEntryFrequencyMaxInterval(),
+		feed.ScheduleNextCheck(config.SchedulerEntryFrequencyMaxInterval()+30))
+}
+
+func TestFeedScheduleNextCheckEntryFrequencyWithFactor(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	t.Setenv("SCHEDULER_ENTRY_FREQUENCY_FACTOR", "2")
+	require.NoError(t, config.Load(""))
+
+	feed := new(Feed).WithWeeklyEntryCount(7 * 24)
+	assert.Equal(t, 30, feed.ScheduleNextCheck(noRefreshDelay))
+}
+
+func TestFeedScheduleNextCheckRoundRobinIgnoresCheckInterval(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, config.Load(""))
+
+	feed := new(Feed).WithWeeklyEntryCount(7 * 24 * 60)
+	assert.Equal(t, config.SchedulerRoundRobinMinInterval(),
+		feed.ScheduleNextCheck(noRefreshDelay))
+}
+
+func TestFeedScheduleNextCheckRefreshInterval(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("POLLING_SCHEDULER", "entry_frequency")
+	require.NoError(t, config.Load(""))
+
+	feed := new(Feed).WithWeeklyEntryCount(7).WithRefreshInterval(5)
+	assert.Equal(t, 5, feed.RefreshInterval())
+
+	timeBefore := time.Now()
+	assert.Equal(t, 5, feed.ScheduleNextCheck(noRefreshDelay))
+	checkTargetInterval(t, feed, 5, timeBefore, "refresh interval")
+
+	assert.Equal(t, 30, feed.ScheduleNextCheck(30),
+		"refresh delay has priority, if it's greater")
+
+	feed.WithRefreshInterval(2 * config.SchedulerEntryFrequencyMaxInterval())
+	assert.Equal(t, 2*config.SchedulerEntryFrequencyMaxInterval(),
+		feed.ScheduleNextCheck(noRefreshDelay))
+
+	feed.WithRefreshInterval(-1)
+	assert.Zero(t, feed.RefreshInterval())
+	assert.Equal(t, 24*60, feed.ScheduleNextCheck(noRefreshDelay))
+}
+
+func TestFeedScheduleNextCheckSkipHoursAndDays(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, config.Load(""))
+
+	interval := config.SchedulerRoundRobinMinInterval()
+	nextCheck := time.Now().UTC().Add(time.Duration(interval) * time.Minute)
+
+	feed := new(Feed).WithSkipHours([]int{nextCheck.Hour(), -1, 24})
+	assert.Equal(t, []int{nextCheck.Hour()}, feed.SkipHours())
+	assert.Greater(t, feed.ScheduleNextCheck(noRefreshDelay), interval)
+	assert.NotEqual(t, nextCheck.Hour(), feed.NextCheckAt.UTC().Hour())
+	assert.Zero(t, feed.NextCheckAt.UTC().Minute())
+
+	feed = new(Feed).WithSkipDays([]time.Weekday{nextCheck.Weekday()})
+	feed.ScheduleNextCheck(noRefreshDelay)
+	assert.Equal(t, (nextCheck.Weekday()+1)%7, feed.NextCheckAt.UTC().Weekday())
+	assert.Zero(t, feed.NextCheckAt.UTC().Hour())
+
+	allHours := make([]int, 24)
+	for i := range allHours {
+		allHours[i] = i
+	}
+	feed = new(Feed).WithSkipHours(allHours)
+	assert.Equal(t, interval, feed.ScheduleNextCheck(noRefreshDelay),
+		"all hours forbidden, skip hints ignored")
+}
+
+func TestFeedScheduleNextCheckUpdatePeriod(t *testing.T) {
+	os.Clearenv()
+	require.NoError(t, config.Load(""))
+
+	remote := new(Feed).WithUpdatePeriod(12 * 60)
+	feed := new(Feed).WithPollingHints(remote)
+	assert.Equal(t, 12*60, feed.ScheduleNextCheck(noRefreshDelay))
+
+	feed.WithUpdatePeriod(7 * 24 * 60)
+	assert.Equal(t, config.SchedulerRoundRobinMaxInterval(),
+		feed.ScheduleNextCheck(noRefreshDelay))
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// FilterAction is what block and keep filter rules do with an entry.
+type FilterAction string
+
+const (
+	FilterActionKeep     FilterAction = "keep"
+	FilterActionBlock    FilterAction = "block"
+	FilterActionMarkRead FilterAction = "mark_read"
+)
+
+// FilterPreviewRequest holds candidate filter rules of a feed. Nil rules are
+// taken from the feed.
+type FilterPreviewRequest struct {
+	BlockAuthors          *[]string `json:"blockAuthors,omitempty"`
+	BlockFilterEntryRules *string   `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  *string   `json:"keep_filter_entry_rules"`
+}
+
+func (self *FilterPreviewRequest) Patch(feed *Feed) {
+	if self.BlockAuthors != nil {
+		feed.WithBlockAuthors(*self.BlockAuthors)
+	}
+
+	if self.BlockFilterEntryRules != nil {
+		feed.Extra.BlockFilterEntryRules = *self.BlockFilterEntryRules
+	}
+
+	if self.KeepFilterEntryRules != nil {
+		feed.Extra.KeepFilterEntryRules = *self.KeepFilterEntryRules
+	}
+}
+
+// FilterPreview reports what filter rules would do with stored entries of a
+// feed and with the items it currently publishes.
+type FilterPreview struct {
+	Entries    []FilterPreviewEntry `json:"entries"`
+	Kept       int                  `json:"kept"`
+	Blocked    int                  `json:"blocked"`
+	MarkedRead int                  `json:"marked_read"`
+	FetchError string               `json:"fetch_error,omitempty"`
+}
+
+type FilterPreviewEntry struct {
+	ID     int64        `json:"id,omitempty"`
+	Title  string       `json:"title"`
+	URL    string       `json:"url"`
+	Author string       `json:"author,omitempty"`
+	Date   time.Time    `json:"published_at"`
+	Stored bool         `json:"stored"`
+	Action FilterAction `json:"action"`
+}
+
+func (self *FilterPreview) Append(entry *Entry, action FilterAction) {
+	self.Entries = append(self.Entries, FilterPreviewEntry{
+		ID:     entry.ID,
+		Title:  entry.Title,
+		URL:    entry.URL,
+		Author: entry.Author,
+		Date:   entry.Date,
+		Stored: entry.ID != 0,
+		Action: action,
+	})
+
+	switch action {
+	case FilterActionKeep:
+		self.Kept++
+	case FilterActionBlock:
+		self.Blocked++
+	case FilterActionMarkRead:
+		self.MarkedRead++
+	}
+}
+
+// FilterRuleHit is how many entries a block or keep rule matched in the feed.
+// FeedID is zero when hits of all feeds are summed up.
+type FilterRuleHit struct {
+	FeedID    int64        `json:"feed_id,omitempty" db:"feed_id"`
+	Action    FilterAction `json:"action" db:"action"`
+	Rule      string       `json:"rule" db:"rule"`
+	Hits      int64        `json:"hits" db:"hits"`
+	LastHitAt time.Time    `json:"last_hit_at" db:"last_hit_at"`
+}
+
+// FilterRuleKey identifies a block or keep rule, by its source line.
+type FilterRuleKey struct {
+	Action FilterAction
+	Rule   string
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// Label is a user defined label of entries, independent from feed categories
+// and entry tags.
+type Label struct {
+	ID         int64     `json:"id" db:"id"`
+	UserID     int64     `json:"user_id" db:"user_id"`
+	Title      string    `json:"title" db:"title"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	EntryCount int       `json:"entry_count" db:"entry_count"`
+}
+
+// LabelRequest represents the request to create or rename a label.
+type LabelRequest struct {
+	Title string `json:"title"`
+}
+
+// EntryLabelsRequest represents the request to set labels of an entry.
+type EntryLabelsRequest struct {
+	LabelIDs []int64 `json:"label_ids"`
+}
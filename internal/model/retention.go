@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "errors"
+
+// Retention is the archiving policy of feed entries, which replaces the global
+// CLEANUP_ARCHIVE_READ_DAYS and CLEANUP_ARCHIVE_UNREAD_DAYS settings. A policy
+// of a feed wins over the policy of its category. Starred entries are never
+// archived.
+type Retention struct {
+	// Never disables archiving of entries.
+	Never bool `json:"never,omitempty"`
+
+	// Days archives read and unread entries not changed for more than the
+	// given number of days.
+	Days int `json:"days,omitempty"`
+
+	// Keep archives entries beyond the given number of the most recently
+	// published entries of the feed.
+	Keep int `json:"keep,omitempty"`
+}
+
+// Validate returns an error if the policy is invalid.
+func (self *Retention) Validate() error {
+	switch {
+	case self.Days < 0:
+		return errors.New("the number of days must be positive")
+	case self.Keep < 0:
+		return errors.New("the number of entries must be positive")
+	case self.Never && (self.Days != 0 || self.Keep != 0):
+		return errors.New("entries can't be kept forever and archived")
+	}
+	return nil
+}
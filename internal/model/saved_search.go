@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// SavedSearch is a named search query of the user, shown as a virtual feed.
+// Token is the secret part of the URLs of its Atom and JSON feeds.
+type SavedSearch struct {
+	ID          int64     `json:"id" db:"id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	Title       string    `json:"title" db:"title"`
+	Query       string    `json:"query" db:"query"`
+	Token       string    `json:"token" db:"token"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UnreadCount int       `json:"unread_count" db:"-"`
+}
+
+// SearchQuery parses the query of the saved search.
+func (self *SavedSearch) SearchQuery(loc *time.Location) (*SearchQuery,
+	error,
+) {
+	return ParseSearchQuery(self.Query, loc)
+}
+
+// SavedSearchRequest represents the request to create or update a saved
+// search.
+type SavedSearchRequest struct {
+	Title string `json:"title"`
+	Query string `json:"query"`
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// ScraperRulesSource is where scraper rules of a web page come from.
+type ScraperRulesSource string
+
+const (
+	ScraperRulesFeed       ScraperRulesSource = "feed"
+	ScraperRulesSite       ScraperRulesSource = "site_rule"
+	ScraperRulesPredefined ScraperRulesSource = "predefined"
+)
+
+// ScraperDiagnostics reports how the web page of an entry or an URL was
+// scraped. Sizes are in bytes and lengths of text in characters.
+type ScraperDiagnostics struct {
+	EntryID    int64  `json:"entry_id,omitempty"`
+	URL        string `json:"url"`
+	FinalURL   string `json:"final_url,omitempty"`
+	Method     string `json:"method,omitempty"`
+	Rules      string `json:"rules,omitempty"`
+	RulesError string `json:"rules_error,omitempty"`
+
+	// Rendered is true when the web page is fetched through the render
+	// service.
+	Rendered bool `json:"rendered,omitempty"`
+
+	RulesSource ScraperRulesSource `json:"rules_source,omitempty"`
+	SiteRuleID  int64              `json:"site_rule_id,omitempty"`
+
+	Pages              int   `json:"pages"`
+	HTMLSize           int64 `json:"html_size"`
+	ContentSize        int64 `json:"content_size"`
+	TextLength         int   `json:"text_length"`
+	OriginalTextLength int   `json:"original_text_length"`
+
+	// Fallback is true when the feed crawler keeps the feed content, because
+	// scraped content is empty or much shorter. Downloads requested by the user
+	// always replace it.
+	Fallback bool   `json:"fallback"`
+	Preview  string `json:"preview"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SearchQuery is a parsed search query. Text is the full-text part, other
+// fields come from "field:value" terms.
+type SearchQuery struct {
+	Text       string
+	Titles     []string
+	Authors    []string
+	Feeds      []string
+	Categories []string
+	Tags       []string
+	URLs       []string
+	Domains    []string
+	Status     string
+	Starred    *bool
+
+	// After is inclusive, Before is exclusive.
+	After  time.Time
+	Before time.Time
+}
+
+// ParseSearchQuery parses a search query like:
+//
+//	author:alice feed:"Go blog" is:starred before:2026-01-01 generics
+//
+// Supported fields are title, author, feed, category, tag, url, domain,
+// status, is, starred, before, after and date. Dates are in the YYYY-MM-DD,
+// YYYY-MM or YYYY format and use the given location. date:A..B matches
+// entries published from the start of A to the end of B. Terms with unknown
+// fields are left in the full-text part.
+func ParseSearchQuery(s string, loc *time.Location) (*SearchQuery, error) {
+	q := &SearchQuery{}
+	var text []string
+	for _, term := range splitSearchQuery(s) {
+		field, value, found := strings.Cut(term, ":")
+		value = strings.Trim(value, `"`)
+		if !found || value == "" {
+			text = append(text, term)
+			continue
+		}
+
+		known, err := q.set(strings.ToLower(field), value, loc)
+		if err != nil {
+			return nil, fmt.Errorf("model: invalid search term %q: %w", term, err)
+		} else if !known {
+			text = append(text, term)
+		}
+	}
+	q.Text = strings.Join(text, " ")
+	return q, nil
+}
+
+// splitSearchQuery splits s by spaces, keeping quoted strings together.
+func splitSearchQuery(s string) []string {
+	var terms []string
+	var term strings.Builder
+	var quoted bool
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if term.Len() != 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+			continue
+		}
+		term.WriteRune(r)
+	}
+
+	if term.Len() != 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+func (self *SearchQuery) set(field, value string, loc *time.Location,
+) (bool, error) {
+	switch field {
+	case "title":
+		self.Titles = append(self.Titles, value)
+	case "author":
+		self.Authors = append(self.Authors, value)
+	case "feed":
+		self.Feeds = append(self.Feeds, value)
+	case "category":
+		self.Categories = append(self.Categories, value)
+	case "tag":
+		self.Tags = append(self.Tags, value)
+	case "url":
+		self.URLs = append(self.URLs, value)
+	case "domain":
+		self.Domains = append(self.Domains,
+			strings.TrimPrefix(strings.ToLower(value), "www."))
+	case "status":
+		return true, self.setStatus(strings.ToLower(value))
+	case "is":
+		switch v := strings.ToLower(value); v {
+		case "starred":
+			self.Starred = new(true)
+		case "unstarred":
+			self.Starred = new(false)
+		default:
+			return true, self.setStatus(v)
+		}
+	case "starred":
+		starred, err := strconv.ParseBool(value)
+		if err != nil {
+			return true, fmt.Errorf("parse starred: %w", err)
+		}
+		self.Starred = &starred
+	case "before":
+		from, _, err := parseSearchDate(value, loc)
+		if err != nil {
+			return true, err
+		}
+		self.Before = from
+	case "after":
+		from, _, err := parseSearchDate(value, loc)
+		if err != nil {
+			return true, err
+		}
+		self.After = from
+	case "date":
+		return true, self.setDateRange(value, loc)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (self *SearchQuery) setStatus(status string) error {
+	switch status {
+	case EntryStatusRead, EntryStatusUnread:
+		self.Status = status
+	default:
+		return fmt.Errorf("unknown status %q", status)
+	}
+	return nil
+}
+
+func (self *SearchQuery) setDateRange(value string, loc *time.Location) error {
+	first, last, found := strings.Cut(value, "..")
+	if !found {
+		last = first
+	}
+
+	if first != "" {
+		from, _, err := parseSearchDate(first, loc)
+		if err != nil {
+			return err
+		}
+		self.After = from
+	}
+
+	if last != "" {
+		_, to, err := parseSearchDate(last, loc)
+		if err != nil {
+			return err
+		}
+		self.Before = to
+	}
+	return nil
+}
+
+// parseSearchDate returns start and end of the day, month or year in s.
+func parseSearchDate(s string, loc *time.Location) (time.Time, time.Time,
+	error,
+) {
+	layouts := [...]struct {
+		layout     string
+		y, m, days int
+	}{
+		{time.DateOnly, 0, 0, 1},
+		{"2006-01", 0, 1, 0},
+		{"2006", 1, 0, 0},
+	}
+
+	for _, l := range layouts {
+		from, err := time.ParseInLocation(l.layout, s, loc)
+		if err == nil {
+			return from, from.AddDate(l.y, l.m, l.days), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// Empty returns true if the query has neither text nor fields.
+func (self *SearchQuery) Empty() bool {
+	return self.Text == "" && len(self.Titles) == 0 &&
+		len(self.Authors) == 0 && len(self.Feeds) == 0 &&
+		len(self.Categories) == 0 && len(self.Tags) == 0 &&
+		len(self.URLs) == 0 && len(self.Domains) == 0 && self.Status == "" &&
+		self.Starred == nil && self.After.IsZero() && self.Before.IsZero()
+}
+
+// SnippetFromHeadline returns a safe HTML snippet from a ts_headline() result
+// of text with HTML entities, where matches are between <mark> and </mark>.
+// Everything but the mark elements is escaped.
+func SnippetFromHeadline(headline string) string {
+	escape := func(s string) string {
+		return html.EscapeString(html.UnescapeString(s))
+	}
+
+	var b strings.Builder
+	for i, part := range strings.Split(headline, "<mark>") {
+		if i == 0 {
+			b.WriteString(escape(part))
+			continue
+		}
+
+		match, text, _ := strings.Cut(part, "</mark>")
+		b.WriteString("<mark>" + escape(match) + "</mark>" + escape(text))
+	}
+	return strings.TrimSpace(b.String())
+}
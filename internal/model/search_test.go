@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	loc := time.FixedZone("test", 3600)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want SearchQuery
+	}{
+		{name: "empty"},
+		{
+			name: "text only",
+			in:   `go "generic types" -java`,
+			want: SearchQuery{Text: `go "generic types" -java`},
+		},
+		{
+			name: "fields",
+			in: `Author:alice feed:"Go blog" category:dev tag:go title:release ` +
+				`url:/posts/ domain:www.Example.org generics`,
+			want: SearchQuery{
+				Text:       "generics",
+				Titles:     []string{"release"},
+				Authors:    []string{"alice"},
+				Feeds:      []string{"Go blog"},
+				Categories: []string{"dev"},
+				Tags:       []string{"go"},
+				URLs:       []string{"/posts/"},
+				Domains:    []string{"example.org"},
+			},
+		},
+		{
+			name: "status and starred",
+			in:   "is:unread is:starred",
+			want: SearchQuery{Status: EntryStatusUnread, Starred: new(true)},
+		},
+		{
+			name: "starred false",
+			in:   "status:read starred:false",
+			want: SearchQuery{Status: EntryStatusRead, Starred: new(false)},
+		},
+		{
+			name: "before and after",
+			in:   "after:2025-12 before:2026-01-01",
+			want: SearchQuery{
+				After:  date(2025, time.December, 1),
+				Before: date(2026, time.January, 1),
+			},
+		},
+		{
+			name: "date",
+			in:   "date:2026-01-15",
+			want: SearchQuery{
+				After:  date(2026, time.January, 15),
+				Before: date(2026, time.January, 16),
+			},
+		},
+		{
+			name: "date range",
+			in:   "date:2025..2026-02",
+			want: SearchQuery{
+				After:  date(2025, time.January, 1),
+				Before: date(2026, time.March, 1),
+			},
+		},
+		{
+			name: "open date range",
+			in:   "date:..2025",
+			want: SearchQuery{Before: date(2026, time.January, 1)},
+		},
+		{
+			name: "unknown fields are text",
+			in:   "https://example.org c++: foo:bar title:",
+			want: SearchQuery{Text: "https://example.org c++: foo:bar title:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSearchQuery(tt.in, loc)
+			require.NoError(t, err)
+			assert.Equal(t, &tt.want, got)
+			assert.Equal(t, tt.in == "", got.Empty())
+		})
+	}
+}
+
+func TestParseSearchQuery_Error(t *testing.T) {
+	for _, s := range []string{
+		"status:removed",
+		"is:everything",
+		"starred:maybe",
+		"before:yesterday",
+		"date:2026-13",
+	} {
+		_, err := ParseSearchQuery(s, time.UTC)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestSnippetFromHeadline(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"  plain text ", "plain text"},
+		{
+			"use <mark>generics</mark> &amp; <mark>types</mark>",
+			"use <mark>generics</mark> &amp; <mark>types</mark>",
+		},
+		{
+			`a &lt;b&gt; "c" <mark>d</mark> <img src=x`,
+			"a &lt;b&gt; &#34;c&#34; <mark>d</mark> &lt;img src=x",
+		},
+		{"<mark>open", "<mark>open</mark>"},
+		{"close</mark> text", "close&lt;/mark&gt; text"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SnippetFromHeadline(tt.in), tt.in)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"strings"
+	"time"
+)
+
+// SiteRule is a set of rules for pages of a domain and its subdomains, shared
+// by all feeds of the user or, when global, of all users. Rules of feeds take
+// precedence over site rules, which take precedence over predefined rules.
+type SiteRule struct {
+	ID           int64     `json:"id" db:"id"`
+	UserID       int64     `json:"user_id" db:"user_id"`
+	Hostname     string    `json:"hostname" db:"hostname"`
+	ScraperRules string    `json:"scraper_rules" db:"scraper_rules"`
+	RewriteRules string    `json:"rewrite_rules" db:"rewrite_rules"`
+	Referer      string    `json:"referer" db:"referer"`
+	UserAgent    string    `json:"user_agent" db:"user_agent"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Global returns true for site rules of all users.
+func (self *SiteRule) Global() bool { return self.UserID == 0 }
+
+// Request returns the site rule as a request to create it.
+func (self *SiteRule) Request() SiteRuleRequest {
+	return SiteRuleRequest{
+		Hostname:     self.Hostname,
+		ScraperRules: self.ScraperRules,
+		RewriteRules: self.RewriteRules,
+		Referer:      self.Referer,
+		UserAgent:    self.UserAgent,
+		Global:       self.Global(),
+	}
+}
+
+// SiteRules is a list of site rules of the user and global ones.
+type SiteRules []SiteRule
+
+// Match returns the site rule of the hostname or of its closest parent
+// domain, or nil if nothing matches. Rules of the user take precedence over
+// global rules of the same domain.
+func (self SiteRules) Match(hostname string) *SiteRule {
+	if len(self) == 0 || hostname == "" {
+		return nil
+	}
+
+	hostname = strings.ToLower(hostname)
+	for {
+		var found *SiteRule
+		for i := range self {
+			r := &self[i]
+			if r.Hostname == hostname && (found == nil || found.Global()) {
+				found = r
+			}
+		}
+		if found != nil {
+			return found
+		}
+
+		_, domain, ok := strings.Cut(hostname, ".")
+		if !ok {
+			return nil
+		}
+		hostname = domain
+	}
+}
+
+// SiteRuleRequest represents the request to create or update a site rule. It's
+// also the format of exported site rules.
+type SiteRuleRequest struct {
+	Hostname     string `json:"hostname" yaml:"hostname"`
+	ScraperRules string `json:"scraper_rules" yaml:"scraper_rules,omitempty"`
+	RewriteRules string `json:"rewrite_rules" yaml:"rewrite_rules,omitempty"`
+	Referer      string `json:"referer" yaml:"referer,omitempty"`
+	UserAgent    string `json:"user_agent" yaml:"user_agent,omitempty"`
+	Global       bool   `json:"global" yaml:"-"`
+}
+
+// UserID returns the owner of the site rule: zero for global rules or
+// userID.
+func (self *SiteRuleRequest) UserID(userID int64) int64 {
+	if self.Global {
+		return 0
+	}
+	return userID
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteRules_Match(t *testing.T) {
+	rules := SiteRules{
+		{ID: 1, Hostname: "example.com"},
+		{ID: 2, UserID: 1, Hostname: "example.com"},
+		{ID: 3, Hostname: "news.example.com"},
+		{ID: 4, Hostname: "example.org"},
+	}
+
+	tests := []struct {
+		hostname string
+		want     int64
+	}{
+		{hostname: "example.com", want: 2},
+		{hostname: "www.example.com", want: 2},
+		{hostname: "news.example.com", want: 3},
+		{hostname: "a.news.EXAMPLE.com", want: 3},
+		{hostname: "example.org", want: 4},
+		{hostname: "example.net"},
+		{hostname: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			got := rules.Match(tt.hostname)
+			if tt.want == 0 {
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, got.ID)
+			}
+		})
+	}
+}
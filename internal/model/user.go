@@ -53,6 +53,7 @@ type UserExtra struct {
 	AlwaysOpenExternalLinks bool        `json:"always_open_external_links,omitempty"`
 	Integration             Integration `json:"integration,omitzero"`
 	OpenExternalLinkSameTab bool        `json:"open_external_link_same_tab,omitempty"`
+	TagEntryRules           string      `json:"tag_entry_rules,omitempty"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -94,6 +95,7 @@ type UserModificationRequest struct {
 	KeepFilterEntryRules            *string  `json:"keep_filter_entry_rules"`
 	AlwaysOpenExternalLinks         *bool    `json:"always_open_external_links,omitempty"`
 	OpenExternalLinkSameTab         *bool    `json:"open_external_link_same_tab,omitempty"`
+	TagEntryRules                   *string  `json:"tag_entry_rules,omitempty"`
 }
 
 // Patch updates the User object with the modification request.
@@ -209,6 +211,10 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.OpenExternalLinkSameTab != nil {
 		user.Extra.OpenExternalLinkSameTab = *u.OpenExternalLinkSameTab
 	}
+
+	if u.TagEntryRules != nil {
+		user.Extra.TagEntryRules = *u.TagEntryRules
+	}
 }
 
 func (u *User) String() string {
@@ -232,6 +238,8 @@ func (u *User) OpenExternalLinkSameTab() bool {
 	return u.Extra.OpenExternalLinkSameTab
 }
 
+func (u *User) TagEntryRules() string { return u.Extra.TagEntryRules }
+
 func (u *User) TargetBlank() template.HTMLAttr {
 	if u.OpenExternalLinkSameTab() {
 		return ""
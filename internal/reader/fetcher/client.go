@@ -110,6 +110,16 @@ func (self *Client) transport() http.RoundTripper {
 func denyDialToPrivate(ctx context.Context, network, address string,
 	_ syscall.RawConn,
 ) error {
+	var reqURL string
+	if req := requestFromContext(ctx); req != nil {
+		reqURL = req.URL.String()
+	}
+	return checkPrivateAddress(address, reqURL)
+}
+
+// checkPrivateAddress returns ErrPrivateNetworkHost if address belongs to a
+// private network and isn't permitted for reqURL.
+func checkPrivateAddress(address, reqURL string) error {
 	host, _, err := net.SplitHostPort(address)
 	if err != nil {
 		return fmt.Errorf("%w: split %q: %w", ErrPrivateNetworkHost, address, err)
@@ -132,11 +142,6 @@ func denyDialToPrivate(ctx context.Context, network, address string,
 		return nil
 	}
 
-	var reqURL string
-	if req := requestFromContext(ctx); req != nil {
-		reqURL = req.URL.String()
-	}
-
 	ok := config.FetcherHostPermitted(address, reqURL) ||
 		config.FetcherHostPermitted(host, reqURL)
 	if !ok {
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/logging"
+)
+
+// render fetches the web page at pageURL through the render service, which
+// executes its JavaScript and returns the resulting HTML.
+//
+// The render service gets the timeout of the request builder and the limits
+// of its own host. The limits of the page host are held until the response is
+// closed, like for pages fetched directly.
+func (self *RequestBuilder) render(ctx context.Context, pageURL string,
+) (*ResponseHandler, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("reader/fetcher: parse page URL: %w", err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("reader/fetcher: unsupported page URL %q",
+			pageURL)
+	}
+
+	if !self.allowPrivateNets {
+		if err := checkPrivateHost(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := self.newRenderRequest(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname := u.Hostname()
+	if err := limits.Acquire(ctx, hostname); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Debug("Rendering web page",
+		slog.String("url", pageURL),
+		slog.String("render_service", self.renderURL.Host),
+		slog.String("render_api", config.RenderServiceAPI()))
+
+	rb := &RequestBuilder{
+		clientTimeout:    self.clientTimeout,
+		allowPrivateNets: true,
+		customized:       true,
+	}
+
+	resp, err := rb.Do(req)
+	if err != nil {
+		limits.Release(hostname)
+		return nil, err
+	}
+	resp.page = u
+	return resp, nil
+}
+
+// checkPrivateHost returns ErrPrivateNetworkHost if the host of u resolves to
+// a private network. The render service resolves it again, so this check
+// only refuses pages, which the fetcher refuses to access directly.
+func checkPrivateHost(ctx context.Context, u *url.URL) error {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return fmt.Errorf("reader/fetcher: lookup %q: %w", u.Hostname(), err)
+	}
+
+	for _, addr := range addrs {
+		address := net.JoinHostPort(addr.Unmap().String(), port)
+		if err := checkPrivateAddress(address, u.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newRenderRequest returns the request to the render service, which renders
+// the web page at u using the API of config.RenderServiceAPI.
+func (self *RequestBuilder) newRenderRequest(ctx context.Context,
+	u *url.URL,
+) (*http.Request, error) {
+	// Cookies and credentials are for the page host only, but the render
+	// service sends its headers with requests to any host of the page.
+	headers := make(map[string]string, len(self.headers))
+	for key := range self.headers {
+		switch key {
+		case "Accept", "Authorization", "Cookie":
+		default:
+			headers[key] = self.headers.Get(key)
+		}
+	}
+
+	var body any
+	switch config.RenderServiceAPI() {
+	case config.RenderServiceSplash:
+		body = struct {
+			URL     string            `json:"url"`
+			Headers map[string]string `json:"headers,omitempty"`
+			Timeout float64           `json:"timeout"`
+		}{
+			URL:     u.String(),
+			Headers: headers,
+			Timeout: self.clientTimeout.Seconds(),
+		}
+	default:
+		type gotoOptions struct {
+			Timeout int64 `json:"timeout"`
+		}
+		body = struct {
+			URL         string            `json:"url"`
+			Headers     map[string]string `json:"setExtraHTTPHeaders,omitempty"`
+			GotoOptions gotoOptions       `json:"gotoOptions"`
+		}{
+			URL:         u.String(),
+			Headers:     headers,
+			GotoOptions: gotoOptions{Timeout: self.clientTimeout.Milliseconds()},
+		}
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("reader/fetcher: marshal render request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		self.renderURL.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("reader/fetcher: create render request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set(uaHeaderName, self.headers.Get(uaHeaderName))
+	return req, nil
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/config"
+)
+
+func TestRequestBuilder_WithRenderService(t *testing.T) {
+	tests := []struct {
+		api  string
+		want map[string]any
+	}{
+		{
+			api: config.RenderServiceBrowserless,
+			want: map[string]any{
+				"url": "https://example.org/article",
+				"setExtraHTTPHeaders": map[string]any{
+					"User-Agent": "test-agent",
+					"Referer":    "https://example.org/",
+				},
+				"gotoOptions": map[string]any{"timeout": float64(20000)},
+			},
+		},
+		{
+			api: config.RenderServiceSplash,
+			want: map[string]any{
+				"url": "https://example.org/article",
+				"headers": map[string]any{
+					"User-Agent": "test-agent",
+					"Referer":    "https://example.org/",
+				},
+				"timeout": float64(20),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.api, func(t *testing.T) {
+			var got map[string]any
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPost, r.Method)
+					assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+					assert.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+					w.Header().Set("Content-Type", "text/html; charset=utf-8")
+					_, _ = w.Write([]byte("<p>Rendered</p>"))
+				}))
+			t.Cleanup(server.Close)
+
+			os.Clearenv()
+			t.Setenv("HTTP_CLIENT_TIMEOUT", "20")
+			t.Setenv("RENDER_SERVICE_URL", server.URL+"/content")
+			t.Setenv("RENDER_SERVICE_API", tt.api)
+			require.NoError(t, config.Load(""))
+
+			resp, err := NewRequestBuilder().
+				WithPrivateNetworks().
+				WithRenderService().
+				WithUserAgent("test-agent").
+				WithHeader("Referer", "https://example.org/").
+				WithCookie("secret=1").
+				Request(t.Context(), "https://example.org/article")
+			require.NoError(t, err)
+			defer resp.Close()
+			require.NoError(t, resp.Err())
+
+			body, lerr := resp.ReadBody()
+			require.Nil(t, lerr)
+			assert.Equal(t, "<p>Rendered</p>", string(body))
+			assert.Equal(t, "https://example.org/article", resp.EffectiveURL())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRequestBuilder_WithRenderService_privateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request to the render service")
+		}))
+	t.Cleanup(server.Close)
+
+	os.Clearenv()
+	t.Setenv("RENDER_SERVICE_URL", server.URL)
+	require.NoError(t, config.Load(""))
+
+	_, err := NewRequestBuilder().WithRenderService().
+		Request(t.Context(), "http://127.0.0.1:8080/article")
+	require.ErrorIs(t, err, ErrPrivateNetworkHost)
+}
+
+func TestRequestBuilder_WithRenderService_disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.WriteHeader(http.StatusOK)
+		}))
+	t.Cleanup(server.Close)
+
+	os.Clearenv()
+	require.NoError(t, config.Load(""))
+
+	resp, err := NewRequestBuilder().WithPrivateNetworks().WithRenderService().
+		Request(t.Context(), server.URL)
+	require.NoError(t, err)
+	defer resp.Close()
+	require.NoError(t, resp.Err())
+	assert.Equal(t, server.URL, resp.EffectiveURL())
+}
@@ -51,6 +51,7 @@ type RequestBuilder struct {
 	proxyRotator     *proxyrotator.ProxyRotator
 	feedProxyURL     string
 	allowPrivateNets bool
+	renderURL        *url.URL
 
 	customized bool
 }
@@ -176,6 +177,13 @@ func (self *RequestBuilder) WithIntegrationDefaults() *RequestBuilder {
 	return self
 }
 
+// WithRenderService makes Request fetch web pages through the render service,
+// if it's configured.
+func (self *RequestBuilder) WithRenderService() *RequestBuilder {
+	self.renderURL = config.RenderServiceURL()
+	return self
+}
+
 func (self *RequestBuilder) proxy() (*url.URL, error) {
 	var proxyURL *url.URL
 	switch {
@@ -245,6 +253,10 @@ func (self *RequestBuilder) NewRequest(ctx context.Context, requestURL string,
 
 func (self *RequestBuilder) Request(ctx context.Context, requestURL string,
 ) (*ResponseHandler, error) {
+	if self.renderURL != nil {
+		return self.render(ctx, requestURL)
+	}
+
 	req, err := self.NewRequest(ctx, requestURL)
 	if err != nil {
 		return nil, err
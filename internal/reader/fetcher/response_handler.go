@@ -33,6 +33,9 @@ type ResponseHandler struct {
 	hostname    string
 	maxBodySize int64
 
+	// page is the URL of the web page, rendered by the render service.
+	page *url.URL
+
 	content    []byte
 	retryAfter time.Time
 
@@ -137,7 +140,12 @@ func (self *ResponseHandler) Header(key string) string {
 
 func (self *ResponseHandler) Err() error { return self.clientErr }
 
-func (self *ResponseHandler) URL() *url.URL { return self.httpResponse.Request.URL }
+func (self *ResponseHandler) URL() *url.URL {
+	if self.page != nil {
+		return self.page
+	}
+	return self.httpResponse.Request.URL
+}
 
 func (self *ResponseHandler) EffectiveURL() string { return self.URL().String() }
 
@@ -249,6 +257,10 @@ func (self *ResponseHandler) Close() {
 	if self.hostname != "" {
 		limits.Release(self.hostname)
 	}
+
+	if self.page != nil {
+		limits.Release(self.page.Hostname())
+	}
 	self.closed = true
 }
 
@@ -0,0 +1,305 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+type matcher interface {
+	Match(entry *model.Entry) bool
+}
+
+// expression is a single line of a filter, either a plain rule or a boolean
+// expression of rules.
+type expression struct {
+	matcher
+
+	source string
+}
+
+// parseLine parses a single line of a filter. Lines in the historical
+// "field=regex" format are parsed by [NewRule] and keep their meaning, so
+// everything after the operator is still the regexp, including quotes, AND
+// and OR. Only lines, which aren't valid historical rules, are parsed as
+// boolean expressions. Enclose an expression in parentheses to be sure it
+// isn't read as a historical rule:
+//
+//	(title="(?i)golang" AND NOT author="(?i)bot")
+//	(title=(?i)golang AND author!=bot)
+//	(tag=news OR tag=politics) AND content!="(?i)sponsored"
+//	has_enclosure AND enclosure_type="^audio/"
+//
+// Inside expressions, values containing spaces or unbalanced parentheses
+// must be quoted. Operators are case insensitive, NOT binds tighter than
+// AND, which binds tighter than OR.
+func parseLine(line string) (*expression, error) {
+	rule, err := NewRule(line)
+	if err == nil {
+		return &expression{matcher: rule, source: line}, nil
+	}
+
+	p := exprParser{s: line}
+	m, err := p.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return &expression{matcher: m, source: line}, nil
+}
+
+func hasKeyword(s, keyword string) bool {
+	if len(s) <= len(keyword) || !strings.EqualFold(s[:len(keyword)], keyword) {
+		return false
+	}
+	switch s[len(keyword)] {
+	case ' ', '\t', '(', '!':
+		return true
+	}
+	return false
+}
+
+type notExpr struct{ m matcher }
+
+func (self notExpr) Match(entry *model.Entry) bool { return !self.m.Match(entry) }
+
+type allOf []matcher
+
+func (self allOf) Match(entry *model.Entry) bool {
+	for _, m := range self {
+		if !m.Match(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+type anyOf []matcher
+
+func (self anyOf) Match(entry *model.Entry) bool {
+	return slices.ContainsFunc(self, func(m matcher) bool {
+		return m.Match(entry)
+	})
+}
+
+// withReadingSpeed sets reading speeds of the user for all rules of the
+// expression.
+func (self *expression) withReadingSpeed(speed, cjkSpeed int) {
+	walkRules(self.matcher, func(r *Rule) { r.WithReadingSpeed(speed, cjkSpeed) })
+}
+
+// walkRules calls fn for every rule of m.
+func walkRules(m matcher, fn func(r *Rule)) {
+	switch m := m.(type) {
+	case *Rule:
+		fn(m)
+	case notExpr:
+		walkRules(m.m, fn)
+	case allOf:
+		for _, m := range m {
+			walkRules(m, fn)
+		}
+	case anyOf:
+		for _, m := range m {
+			walkRules(m, fn)
+		}
+	}
+}
+
+// exprParser is a recursive descent parser of the grammar:
+//
+//	or    = and { "OR" and }
+//	and   = unary { "AND" unary }
+//	unary = ( "NOT" | "!" ) unary | "(" or ")" | rule
+//	rule  = field [ ( "=" | "!=" | "<" | "<=" | ">" | ">=" ) value ]
+//	value = quoted | bare
+type exprParser struct {
+	s   string
+	pos int
+}
+
+func (self *exprParser) Parse() (matcher, error) {
+	m, err := self.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	self.skipSpace()
+	if self.pos < len(self.s) {
+		return nil, fmt.Errorf("unexpected %q in %q", self.s[self.pos:], self.s)
+	}
+	return m, nil
+}
+
+func (self *exprParser) parseOr() (matcher, error) {
+	m, err := self.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []matcher{m}
+	for self.keyword("or") {
+		m, err := self.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+
+	if len(items) == 1 {
+		return items[0], nil
+	}
+	return anyOf(items), nil
+}
+
+func (self *exprParser) parseAnd() (matcher, error) {
+	m, err := self.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []matcher{m}
+	for self.keyword("and") {
+		m, err := self.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, m)
+	}
+
+	if len(items) == 1 {
+		return items[0], nil
+	}
+	return allOf(items), nil
+}
+
+func (self *exprParser) parseUnary() (matcher, error) {
+	self.skipSpace()
+	switch {
+	case self.consume('!'), self.keyword("not"):
+		m, err := self.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{m}, nil
+	case self.consume('('):
+		m, err := self.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		self.skipSpace()
+		if !self.consume(')') {
+			return nil, fmt.Errorf("missing closing parenthesis in %q", self.s)
+		}
+		return m, nil
+	}
+	return self.parseRule()
+}
+
+func (self *exprParser) parseRule() (*Rule, error) {
+	start := self.pos
+	for self.pos < len(self.s) && isFieldChar(self.s[self.pos]) {
+		self.pos++
+	}
+
+	field := self.s[start:self.pos]
+	if field == "" {
+		return nil, fmt.Errorf("expected field at offset %d in %q", start, self.s)
+	}
+
+	self.skipSpace()
+	op, negate, n := cutOperator(self.s[self.pos:])
+	if op == "" {
+		if !isFlagField(strings.ToLower(field)) {
+			return nil, fmt.Errorf("expected operator after %q in %q",
+				field, self.s)
+		}
+		rule := &Rule{field: strings.ToLower(field)}
+		return rule, rule.init()
+	}
+	self.pos += n
+
+	self.skipSpace()
+	filter, err := self.value()
+	if err != nil {
+		return nil, err
+	} else if filter == "" {
+		return nil, fmt.Errorf("empty filter for %q in %q", field, self.s)
+	}
+
+	rule := &Rule{
+		field:  strings.ToLower(field),
+		op:     op,
+		filter: filter,
+		negate: negate,
+	}
+	return rule, rule.init()
+}
+
+func (self *exprParser) value() (string, error) {
+	if !self.consume('"') {
+		// Balanced parentheses, like in "(?i)golang", are a part of the value,
+		// an unbalanced one closes the group.
+		start, depth := self.pos, 0
+		for ; self.pos < len(self.s) && !isSpace(self.s[self.pos]); self.pos++ {
+			if c := self.s[self.pos]; c == '(' {
+				depth++
+			} else if c == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		return self.s[start:self.pos], nil
+	}
+
+	// Only \" is an escape sequence, so regexp escapes like \d or \\ are kept
+	// as is.
+	var b strings.Builder
+	for self.pos < len(self.s) {
+		c := self.s[self.pos]
+		self.pos++
+		switch {
+		case c == '"':
+			return b.String(), nil
+		case c == '\\' && self.pos < len(self.s) && self.s[self.pos] == '"':
+			b.WriteByte('"')
+			self.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted value in %q", self.s)
+}
+
+func (self *exprParser) keyword(keyword string) bool {
+	self.skipSpace()
+	if !hasKeyword(self.s[self.pos:], keyword) {
+		return false
+	}
+	self.pos += len(keyword)
+	return true
+}
+
+func (self *exprParser) consume(c byte) bool {
+	if self.pos < len(self.s) && self.s[self.pos] == c {
+		self.pos++
+		return true
+	}
+	return false
+}
+
+func (self *exprParser) skipSpace() {
+	for self.pos < len(self.s) && isSpace(self.s[self.pos]) {
+		self.pos++
+	}
+}
+
+func isSpace(c byte) bool { return c == ' ' || c == '\t' }
+
+func isFieldChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' || c == '_'
+}
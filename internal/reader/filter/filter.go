@@ -59,6 +59,7 @@ func (self *feedFilter) DeleteEntries(ctx context.Context) error {
 		slog.String("url", self.feed.FeedURL)))
 	block = block.WithLogger(log.With(slog.String("filter_action", "block")))
 	keep = keep.WithLogger(log.With(slog.String("filter_action", "allow")))
+	self.countHits(block, keep)
 
 	maxAge := config.FilterEntryMaxAge()
 	seen := makeUniqEntries(self.feed)
@@ -72,7 +73,7 @@ func (self *feedFilter) DeleteEntries(ctx context.Context) error {
 		case e.Stored():
 			self.feed.IncFilteredByStored()
 			return true
-		case blockAuthors.Match(e) || block.Match(e) || !keep.Allow(e):
+		case blocked(e, blockAuthors, block, keep):
 			self.feed.IncFilteredByRules()
 			if !self.blockMarkRead() {
 				return true
@@ -87,6 +88,51 @@ func (self *feedFilter) DeleteEntries(ctx context.Context) error {
 	return nil
 }
 
+// Preview returns what block and keep rules of the feed would do with entries,
+// without changing them. Unlike [feedFilter.DeleteEntries], entries aren't
+// filtered by age, uniqueness or whether they are already stored.
+func (self *feedFilter) Preview(entries model.Entries) (*model.FilterPreview,
+	error,
+) {
+	block, keep, err := self.filterRules()
+	if err != nil {
+		return nil, fmt.Errorf("reader/filter: feed rules: %w", err)
+	}
+
+	blockAuthors := NewAuthors(self.feed.BlockAuthors())
+	preview := &model.FilterPreview{
+		Entries: make([]model.FilterPreviewEntry, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		switch {
+		case !blocked(e, blockAuthors, block, keep):
+			preview.Append(e, model.FilterActionKeep)
+		case self.blockMarkRead():
+			preview.Append(e, model.FilterActionMarkRead)
+		default:
+			preview.Append(e, model.FilterActionBlock)
+		}
+	}
+	return preview, nil
+}
+
+// countHits counts rules matching entries in the feed, see
+// [model.Feed.FilterHits].
+func (self *feedFilter) countHits(block, keep *Filter) {
+	block.WithHits(func(rule string) {
+		self.feed.IncFilterHit(model.FilterActionBlock, rule)
+	})
+	keep.WithHits(func(rule string) {
+		self.feed.IncFilterHit(model.FilterActionKeep, rule)
+	})
+}
+
+func blocked(e *model.Entry, blockAuthors *authors, block, keep *Filter,
+) bool {
+	return blockAuthors.Match(e) || block.Match(e) || !keep.Allow(e)
+}
+
 func (self *feedFilter) filterRules() (*Filter, *Filter, error) {
 	block, err := self.blockRules()
 	if err != nil {
@@ -109,7 +155,8 @@ func (self *feedFilter) blockRules() (*Filter, error) {
 		return nil, fmt.Errorf(
 			"building block filter from sets=user,category,feed: %w", err)
 	}
-	return block, nil
+	return block.WithReadingSpeed(self.user.DefaultReadingSpeed,
+		self.user.CJKReadingSpeed), nil
 }
 
 func (self *feedFilter) keepRules() (*Filter, error) {
@@ -120,7 +167,8 @@ func (self *feedFilter) keepRules() (*Filter, error) {
 		return nil, fmt.Errorf(
 			"building keep filter from sets=user,feed: %w", err)
 	}
-	return keep, nil
+	return keep.WithReadingSpeed(self.user.DefaultReadingSpeed,
+		self.user.CJKReadingSpeed), nil
 }
 
 func (self *feedFilter) blockMarkRead() bool {
@@ -5,6 +5,7 @@ package filter // import "miniflux.app/v2/internal/reader/filter"
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -1093,3 +1094,231 @@ func TestBlockedEntryWithDefaultGlobalMaxAge(t *testing.T) {
 	require.NoError(t, DeleteEntries(t.Context(), &user, &feed))
 	assert.Len(t, feed.Entries, 1)
 }
+
+func TestFilterExpressions(t *testing.T) {
+	entry := &model.Entry{
+		Title:   "Golang 1.26 released",
+		Author:  "Release Bot",
+		URL:     "https://example.org/go",
+		Content: "This post is sponsored by nobody",
+		Tags:    []string{"news", "programming"},
+	}
+
+	tests := []struct {
+		rules    string
+		expected bool
+		err      bool
+	}{
+		{rules: `(title=Golang AND author!=bot)`, expected: true},
+		{rules: `(title=Golang OR has_enclosure)`, expected: true},
+		{rules: `(title=rust AND author=bot)`},
+		{rules: `(title=(?i)golang AND author!=(?i)bot)`},
+		{rules: `(title=(?i)golang AND author=(?i)bot)`, expected: true},
+		{rules: `(title="(?i)golang" AND NOT author="(?i)bot")`},
+		{rules: `(title="(?i)golang" AND author="(?i)bot")`, expected: true},
+		{rules: `(title=rust OR title="(?i)golang") AND tag=news`, expected: true},
+		{rules: `(title=rust OR title=(?i)golang) AND tag=news`, expected: true},
+		{rules: `(title=rust OR title=python) AND tag=news`},
+		{rules: `!(tag=sports OR tag=politics)`, expected: true},
+		{rules: `not tag=news`},
+		{rules: `NOT (tag=news) or url="example\.org"`, expected: true},
+		{rules: `(tag="news" OR title=rust AND author=nobody)`, expected: true},
+		{rules: `(tag=news OR title=rust) AND author=nobody`},
+		{rules: `(content!="(?i)sponsored by")`},
+		{rules: `(content="is \"sponsored\"")`},
+		{rules: `(content="sponsored by \w+")`, expected: true},
+		{rules: `has_enclosure OR title=Golang`, expected: true},
+		// Historical format: everything after the operator is the regexp, even
+		// quotes, AND and OR.
+		{rules: `title=(?i)golang (1\.\d+) released`, expected: true},
+		{rules: `title=(?i)golang and released`},
+		{rules: `title=rock and roll`},
+		{rules: `title=Golang AND author!=bot`},
+		{rules: `title=(?i)golang AND author!=(?i)bot`},
+		{rules: `content="sponsored"`},
+		{rules: `content!="sponsored"`, expected: true},
+		{rules: `content=sponsored by \w+`, expected: true},
+		{rules: `title="golang" author=bot`},
+		{rules: `EntryTitle!=(?i)rust`, expected: true},
+		{rules: `EntryTitle != (?i)golang`},
+		{rules: `title=[a-z`, err: true},
+		{rules: `(title=golang`, err: true},
+		{rules: `(title="golang`, err: true},
+		{rules: `(title="golang" AND`, err: true},
+		{rules: `(title="golang") AND`, err: true},
+		{rules: `(title="golang" author=bot)`, err: true},
+		{rules: `(unknown=golang)`, err: true},
+		{rules: `(title="")`, err: true},
+		{rules: `(title="[a-z")`, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			f, err := NewCombinedFilter(tt.rules)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, f.Match(entry))
+		})
+	}
+}
+
+func TestNumericAndEnclosureFilters(t *testing.T) {
+	podcast := &model.Entry{
+		Title:       "Episode 42",
+		Content:     "<p>Show notes</p>",
+		ReadingTime: 45,
+		Extra: model.EntryExtra{
+			Enclosures: model.EnclosureList{
+				{
+					URL:      "https://example.org/42.mp3",
+					MimeType: "audio/mpeg",
+					Duration: 2730,
+				},
+			},
+		},
+	}
+	link := &model.Entry{
+		Title:   "Interesting link",
+		Content: `<a href="https://example.org">example.org</a>`,
+	}
+
+	tests := []struct {
+		rules   string
+		podcast bool
+		link    bool
+		err     bool
+	}{
+		{rules: "readingtime>30", podcast: true},
+		{rules: "readingtime >= 45", podcast: true},
+		{rules: "readingtime<45", link: true},
+		{rules: "readingtime=1", link: true},
+		{rules: "readingtime!=45", link: true},
+		{rules: "contentlength<20", podcast: true, link: true},
+		{rules: "contentlength<=10", podcast: true},
+		{rules: "contentlength>10", link: true},
+		{rules: "enclosure_type=^audio/", podcast: true},
+		{rules: "enclosure_type=^video/"},
+		{rules: "enclosure_type!=^video/", podcast: true, link: true},
+		{rules: "has_enclosure", podcast: true},
+		{rules: "has_enclosure=false", link: true},
+		{rules: "has_enclosure!=true", link: true},
+		{rules: "has_enclosure AND readingtime>30", podcast: true},
+		{rules: "enclosure_duration>=45", podcast: true},
+		{rules: "enclosure_duration<46", podcast: true},
+		{rules: "enclosure_duration>45"},
+		{rules: "enclosure_duration!=45", link: true},
+		{rules: "enclosure_duration=abc", err: true},
+		{rules: "NOT has_enclosure AND contentlength<100", link: true},
+		{rules: `(has_enclosure OR title="(?i)link")`, podcast: true, link: true},
+		{rules: "readingtime>abc", err: true},
+		{rules: "readingtime>-1", err: true},
+		{rules: "title>5", err: true},
+		{rules: "has_enclosure>1", err: true},
+		{rules: "has_enclosure=maybe", err: true},
+		{rules: "(readingtime)", err: true},
+		{rules: "readingtime", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			f, err := NewCombinedFilter(tt.rules)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.podcast, f.Match(podcast), "podcast")
+			assert.Equal(t, tt.link, f.Match(link), "link")
+		})
+	}
+}
+
+func TestReadingTimeFilter_readingSpeed(t *testing.T) {
+	entry := &model.Entry{
+		Content: "<p>" + strings.Repeat("word ", 530) + "</p>",
+	}
+
+	for _, rules := range [...]string{"readingtime>=2", "(readingtime>=2)"} {
+		f, err := NewCombinedFilter(rules)
+		require.NoError(t, err)
+		assert.True(t, f.Match(entry), "default reading speed")
+		f.WithReadingSpeed(1000, 1000)
+		assert.False(t, f.Match(entry), "reading speed of the user")
+	}
+
+	tagger, err := NewTagger("long: readingtime>=5")
+	require.NoError(t, err)
+	tagger.WithReadingSpeed(100, 100)
+	assert.True(t, tagger.Apply(entry))
+	assert.Equal(t, []string{"long"}, entry.Tags)
+}
+
+func TestPreview(t *testing.T) {
+	require.NoError(t, config.Load(""))
+	entries := model.Entries{
+		{ID: 1, Title: "Kept entry"},
+		{Title: "Blocked entry"},
+		{ID: 2, Title: "Blocked entry", Author: "Spammer"},
+	}
+
+	user := model.User{KeepFilterEntryRules: "title=(?i)entry"}
+	feed := model.Feed{
+		Category: &model.Category{},
+		Extra: model.FeedExtra{
+			BlockAuthors:          []string{"Spammer"},
+			BlockFilterEntryRules: "title=(?i)blocked",
+		},
+	}
+
+	preview, err := NewFeedFilter(&user, &feed).Preview(entries)
+	require.NoError(t, err)
+	require.Len(t, preview.Entries, len(entries))
+	assert.Equal(t, 1, preview.Kept)
+	assert.Equal(t, 2, preview.Blocked)
+	assert.Zero(t, preview.MarkedRead)
+
+	assert.Equal(t, model.FilterActionKeep, preview.Entries[0].Action)
+	assert.True(t, preview.Entries[0].Stored)
+	assert.Equal(t, model.FilterActionBlock, preview.Entries[1].Action)
+	assert.False(t, preview.Entries[1].Stored)
+	assert.Len(t, entries, 3, "entries must not be changed")
+
+	feed.WithBlockMarkRead(true)
+	preview, err = NewFeedFilter(&user, &feed).Preview(entries)
+	require.NoError(t, err)
+	assert.Equal(t, 1, preview.Kept)
+	assert.Zero(t, preview.Blocked)
+	assert.Equal(t, 2, preview.MarkedRead)
+	assert.Equal(t, model.FilterActionMarkRead, preview.Entries[2].Action)
+
+	feed.Extra.BlockFilterEntryRules = "title=[a-z"
+	_, err = NewFeedFilter(&user, &feed).Preview(entries)
+	require.Error(t, err)
+}
+
+func TestFilterHits(t *testing.T) {
+	require.NoError(t, config.Load(""))
+	user := model.User{
+		BlockFilterEntryRules: "title=(?i)spam",
+		KeepFilterEntryRules:  "title=.",
+	}
+	feed := model.Feed{
+		Category: &model.Category{},
+		Entries: model.Entries{
+			{Hash: "1", Title: "Spam one", Date: time.Now()},
+			{Hash: "2", Title: "Spam two", Date: time.Now()},
+			{Hash: "3", Title: "Ham", Date: time.Now()},
+			{Hash: "4", Date: time.Now()},
+		},
+	}
+
+	require.NoError(t, DeleteEntries(t.Context(), &user, &feed))
+	require.Len(t, feed.Entries, 1)
+	assert.Equal(t, map[model.FilterRuleKey]int{
+		{Action: model.FilterActionBlock, Rule: "title=(?i)spam"}: 2,
+		{Action: model.FilterActionKeep, Rule: "title=."}:         1,
+	}, feed.FilterHits())
+}
@@ -0,0 +1,34 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/metric"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// StoreHits adds hits of rules, counted while filtering entries of feed, to
+// persistent counters of the user and to metrics.
+func StoreHits(ctx context.Context, store *storage.Storage, userID int64,
+	feed *model.Feed,
+) error {
+	hits := feed.FilterHits()
+	if len(hits) == 0 {
+		return nil
+	}
+
+	if config.HasMetricsCollector() {
+		for k, n := range hits {
+			metric.FilterRuleHits.WithLabelValues(string(k.Action)).Add(float64(n))
+		}
+	}
+
+	err := store.IncFilterRuleHits(ctx, userID, feed.ID, hits)
+	if err != nil {
+		return fmt.Errorf("reader/filter: store rule hits: %w", err)
+	}
+	return nil
+}
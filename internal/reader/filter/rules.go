@@ -5,9 +5,13 @@ import (
 	"log/slog"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/readingtime"
+	"miniflux.app/v2/internal/reader/sanitizer"
 )
 
 func New(s string) (*Filter, error) {
@@ -22,7 +26,7 @@ func NewCombinedFilter(humanRules ...string) (*Filter, error) {
 		}
 		size += strings.Count(s, "\n") + 1
 	}
-	rules := make([]*Rule, 0, size)
+	rules := make([]*expression, 0, size)
 
 	for j, s := range humanRules {
 		if strings.TrimSpace(s) == "" {
@@ -35,7 +39,7 @@ func NewCombinedFilter(humanRules ...string) (*Filter, error) {
 			if line == "" {
 				continue
 			}
-			rule, err := NewRule(line)
+			rule, err := parseLine(line)
 			if err != nil {
 				return nil, fmt.Errorf("parse rule set=%d line=%d: %w", j+1, i, err)
 			}
@@ -46,8 +50,9 @@ func NewCombinedFilter(humanRules ...string) (*Filter, error) {
 }
 
 type Filter struct {
-	rules  []*Rule
+	rules  []*expression
 	logger *slog.Logger
+	hit    func(rule string)
 }
 
 func (self *Filter) WithLogger(l *slog.Logger) *Filter {
@@ -55,13 +60,20 @@ func (self *Filter) WithLogger(l *slog.Logger) *Filter {
 	return self
 }
 
+// WithHits sets fn, called with the source line of the first rule matching an
+// entry.
+func (self *Filter) WithHits(fn func(rule string)) *Filter {
+	self.hit = fn
+	return self
+}
+
 func (self *Filter) Concat(filters ...*Filter) *Filter {
 	size := len(self.rules)
 	for _, f := range filters {
 		size += len(f.rules)
 	}
 
-	rules := slices.Grow[[]*Rule](nil, size)
+	rules := slices.Grow[[]*expression](nil, size)
 	rules = append(rules, self.rules...)
 	for _, f := range filters {
 		rules = append(rules, f.rules...)
@@ -70,22 +82,34 @@ func (self *Filter) Concat(filters ...*Filter) *Filter {
 }
 
 func (self *Filter) Match(entry *model.Entry) bool {
-	return slices.ContainsFunc(self.rules, func(rule *Rule) bool {
+	return slices.ContainsFunc(self.rules, func(rule *expression) bool {
 		if rule.Match(entry) {
 			self.logMatch(entry, rule)
+			if self.hit != nil {
+				self.hit(rule.source)
+			}
 			return true
 		}
 		return false
 	})
 }
 
-func (self *Filter) logMatch(entry *model.Entry, rule *Rule) {
+func (self *Filter) logMatch(entry *model.Entry, rule *expression) {
 	if self.logger == nil {
 		return
 	}
 	self.logger.Debug("Filtering entry based on rule",
 		slog.String("entry_url", entry.URL),
-		slog.String("filter_rule", rule.filter))
+		slog.String("filter_rule", rule.source))
+}
+
+// WithReadingSpeed sets reading speeds of the user, used by the readingtime
+// field to estimate the reading time of entries without it.
+func (self *Filter) WithReadingSpeed(speed, cjkSpeed int) *Filter {
+	for _, rule := range self.rules {
+		rule.withReadingSpeed(speed, cjkSpeed)
+	}
+	return self
 }
 
 func (self *Filter) Allow(entry *model.Entry) bool {
@@ -97,31 +121,78 @@ func (self *Filter) Allow(entry *model.Entry) bool {
 
 type Rule struct {
 	field  string
+	op     string
 	filter string
+	negate bool
+
+	re   *regexp.Regexp
+	n    int
+	flag bool
 
-	re *regexp.Regexp
+	readingSpeed    int
+	cjkReadingSpeed int
 }
 
+// Default reading speeds of new users, used by the readingtime field when the
+// entry has no reading time yet and reading speeds of the user aren't set,
+// see [Filter.WithReadingSpeed].
+const (
+	defaultReadingSpeed    = 265
+	defaultCJKReadingSpeed = 500
+)
+
+// NewRule parses a single "field=regex" rule. Everything after the first
+// operator is the filter, so it may contain spaces and parentheses.
+// "field!=regex" negates the rule. Numeric fields also accept "<", "<=", ">"
+// and ">=", like "readingtime>5" or "enclosure_duration<10", in minutes, and
+// has_enclosure may be used alone.
 func NewRule(s string) (*Rule, error) {
-	field, filter, found := strings.Cut(strings.TrimSpace(s), "=")
-	if !found {
-		return nil, fmt.Errorf("unexpected rule format %q", s)
+	s = strings.TrimSpace(s)
+	i := strings.IndexAny(s, "=<>!")
+	if i < 0 {
+		field := strings.ToLower(s)
+		if !isFlagField(field) {
+			return nil, fmt.Errorf("unexpected rule format %q", s)
+		}
+		self := &Rule{field: field}
+		return self, self.init()
 	}
 
-	field = strings.ToLower(strings.TrimSpace(field))
+	field := strings.ToLower(strings.TrimSpace(s[:i]))
 	if field == "" {
 		return nil, fmt.Errorf("empty field in %q", s)
 	}
 
-	filter = strings.TrimSpace(filter)
+	op, negate, n := cutOperator(s[i:])
+	if op == "" {
+		return nil, fmt.Errorf("unexpected rule format %q", s)
+	}
+
+	filter := strings.TrimSpace(s[i+n:])
 	if filter == "" {
 		return nil, fmt.Errorf("empty filter in %q", s)
 	}
 
-	self := &Rule{field: field, filter: filter}
+	self := &Rule{field: field, op: op, filter: filter, negate: negate}
 	return self, self.init()
 }
 
+// cutOperator returns the operator s starts with and its length. "!=" is
+// returned as "=" with negate set.
+func cutOperator(s string) (op string, negate bool, n int) {
+	for _, op := range [...]string{"!=", ">=", "<=", "=", ">", "<"} {
+		if strings.HasPrefix(s, op) {
+			if op == "!=" {
+				return "=", true, len(op)
+			}
+			return op, false, len(op)
+		}
+	}
+	return "", false, 0
+}
+
+func isFlagField(field string) bool { return field == "has_enclosure" }
+
 func (self *Rule) init() error {
 	switch self.field {
 	case
@@ -131,21 +202,68 @@ func (self *Rule) init() error {
 		"entrytag", "tag",
 		"entrytitle", "title",
 		"entryurl", "url",
+		"enclosure_type",
 		"any":
 
+		if err := self.checkOperator("="); err != nil {
+			return err
+		}
 		re, err := regexp.Compile(self.filter)
 		if err != nil {
 			return fmt.Errorf("compile rule regexp %q: %w", self.filter, err)
 		}
 		self.re = re
 	case "entrydate", "date":
+		return self.checkOperator("=")
+	case "readingtime", "contentlength", "enclosure_duration":
+		if err := self.checkOperator("=", "<", "<=", ">", ">="); err != nil {
+			return err
+		}
+		n, err := strconv.Atoi(self.filter)
+		if err != nil || n < 0 {
+			return fmt.Errorf("field %q expects a positive number, got %q",
+				self.field, self.filter)
+		}
+		self.n = n
+	case "has_enclosure":
+		if self.op == "" {
+			self.flag = true
+			return nil
+		} else if err := self.checkOperator("="); err != nil {
+			return err
+		}
+		b, err := strconv.ParseBool(self.filter)
+		if err != nil {
+			return fmt.Errorf("field %q expects a boolean, got %q",
+				self.field, self.filter)
+		}
+		self.flag = b
 	default:
 		return fmt.Errorf("unknown field %q", self.field)
 	}
 	return nil
 }
 
+func (self *Rule) checkOperator(allowed ...string) error {
+	if !slices.Contains(allowed, self.op) {
+		return fmt.Errorf("operator %q not supported by field %q",
+			self.op, self.field)
+	}
+	return nil
+}
+
+// WithReadingSpeed sets reading speeds of the user, used by the readingtime
+// field to estimate the reading time of entries without it.
+func (self *Rule) WithReadingSpeed(speed, cjkSpeed int) *Rule {
+	self.readingSpeed, self.cjkReadingSpeed = speed, cjkSpeed
+	return self
+}
+
 func (self *Rule) Match(entry *model.Entry) bool {
+	return self.match(entry) != self.negate
+}
+
+func (self *Rule) match(entry *model.Entry) bool {
 	switch self.field {
 	case "entryauthor", "author":
 		return self.re.MatchString(entry.Author)
@@ -163,6 +281,23 @@ func (self *Rule) Match(entry *model.Entry) bool {
 		return self.re.MatchString(entry.Title)
 	case "entryurl", "url":
 		return self.re.MatchString(entry.URL)
+	case "enclosure_type":
+		return slices.ContainsFunc(entry.Enclosures(),
+			func(enc model.Enclosure) bool {
+				return self.re.MatchString(enc.MimeType)
+			})
+	case "has_enclosure":
+		return (len(entry.Enclosures()) != 0) == self.flag
+	case "readingtime":
+		return self.compare(self.entryReadingTime(entry))
+	case "contentlength":
+		return self.compare(contentLength(entry))
+	case "enclosure_duration":
+		// Enclosures without a known duration don't match.
+		return slices.ContainsFunc(entry.Enclosures(),
+			func(enc model.Enclosure) bool {
+				return enc.Duration > 0 && self.compare(int(enc.Duration/60))
+			})
 	}
 
 	return self.re.MatchString(entry.Author) ||
@@ -174,3 +309,43 @@ func (self *Rule) Match(entry *model.Entry) bool {
 			return self.re.MatchString(tag)
 		})
 }
+
+func (self *Rule) compare(n int) bool {
+	switch self.op {
+	case "<":
+		return n < self.n
+	case "<=":
+		return n <= self.n
+	case ">":
+		return n > self.n
+	case ">=":
+		return n >= self.n
+	}
+	return n == self.n
+}
+
+// entryReadingTime returns the reading time of entry in minutes. Entries are
+// filtered before their reading time is estimated, so it's only set at this
+// point if the feed provided it, like the duration of a podcast episode, or
+// the entry is already stored.
+func (self *Rule) entryReadingTime(entry *model.Entry) int {
+	if entry.ReadingTime != 0 || entry.Content == "" {
+		return entry.ReadingTime
+	}
+
+	speed, cjkSpeed := self.readingSpeed, self.cjkReadingSpeed
+	if speed <= 0 {
+		speed = defaultReadingSpeed
+	}
+	if cjkSpeed <= 0 {
+		cjkSpeed = defaultCJKReadingSpeed
+	}
+	return readingtime.EstimateReadingTime(entry.Content, speed, cjkSpeed)
+}
+
+// contentLength returns the number of characters of entry content, without
+// HTML tags.
+func contentLength(entry *model.Entry) int {
+	return utf8.RuneCountInString(
+		strings.TrimSpace(sanitizer.StripTags(entry.Content)))
+}
@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"miniflux.app/v2/internal/logging"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// storedBatchSize is how many stored entries are filtered at once.
+const storedBatchSize = 500
+
+// FilterStoredEntries applies block and keep rules of the user, category and
+// feeds to their unread and not starred stored entries. Blocked entries are
+// marked as read, when block mark read is enabled, or removed. It returns the
+// number of changed entries.
+//
+// Only hits of block rules matching changed entries are counted, so applying
+// filters again doesn't count entries, which are kept, twice.
+func FilterStoredEntries(ctx context.Context, store *storage.Storage,
+	user *model.User, feeds ...*model.Feed,
+) (int, error) {
+	var total int
+	for _, feed := range feeds {
+		n, err := NewFeedFilter(user, feed).FilterStoredEntries(ctx, store)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (self *feedFilter) FilterStoredEntries(ctx context.Context,
+	store *storage.Storage,
+) (int, error) {
+	block, keep, err := self.filterRules()
+	if err != nil {
+		return 0, fmt.Errorf("reader/filter: feed rules: %w", err)
+	}
+
+	blockAuthors := NewAuthors(self.feed.BlockAuthors())
+	if len(block.rules) == 0 && len(keep.rules) == 0 &&
+		len(blockAuthors.block) == 0 {
+		return 0, nil
+	}
+
+	var rule string
+	block.WithHits(func(r string) { rule = r })
+	status := model.EntryStatusRemoved
+	if self.blockMarkRead() {
+		status = model.EntryStatusRead
+	}
+
+	var total int
+	var lastID int64
+	for {
+		entries, err := store.NewEntryQueryBuilder(self.user.ID).
+			WithFeedID(self.feed.ID).
+			WithStatus(model.EntryStatusUnread).
+			WithStarred(false).
+			WithContent(true).
+			AfterEntryID(lastID).
+			WithSorting("id", "asc").
+			WithLimit(storedBatchSize).
+			GetEntries(ctx)
+		if err != nil {
+			return total, fmt.Errorf("reader/filter: get stored entries: %w", err)
+		} else if len(entries) == 0 {
+			break
+		}
+
+		var ids []int64
+		var rules []string
+		for _, e := range entries {
+			rule = ""
+			if blocked(e, blockAuthors, block, keep) {
+				ids = append(ids, e.ID)
+				if rule != "" {
+					rules = append(rules, rule)
+				}
+			}
+		}
+
+		if len(ids) != 0 {
+			n, err := store.UpdateEntriesStatus(ctx, self.user.ID, ids, status)
+			if err != nil {
+				return total, fmt.Errorf("reader/filter: update stored entries: %w",
+					err)
+			}
+			total += n
+			for _, r := range rules {
+				self.feed.IncFilterHit(model.FilterActionBlock, r)
+			}
+		}
+
+		if len(entries) < storedBatchSize {
+			break
+		}
+		lastID = entries[len(entries)-1].ID
+	}
+
+	if err := StoreHits(ctx, store, self.user.ID, self.feed); err != nil {
+		return total, err
+	}
+
+	logging.FromContext(ctx).Info("Filtered stored entries",
+		slog.Int64("user_id", self.user.ID),
+		slog.GroupAttrs("feed",
+			slog.Int64("id", self.feed.ID),
+			slog.String("url", self.feed.FeedURL)),
+		slog.String("status", status),
+		slog.Int("changed", total))
+	return total, nil
+}
@@ -0,0 +1,95 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Tagger adds tags to entries matching tag rules.
+type Tagger struct {
+	rules []tagRule
+}
+
+type tagRule struct {
+	tag  string
+	expr *expression
+}
+
+// NewTagger parses tag rules, one per line, in the "tag: rule" format, where
+// rule uses the syntax of block and keep rules:
+//
+//	security: title=(?i)\b(cve|vulnerability)\b
+//	release: (title="(?i)\brelease\b" AND NOT tag="(?i)sponsored")
+func NewTagger(s string) (*Tagger, error) {
+	var rules []tagRule
+	var i int
+	for line := range strings.SplitSeq(s, "\n") {
+		i++
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line == "" {
+			continue
+		}
+
+		rule, err := parseTagRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("parse tag rule line=%d: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return &Tagger{rules: rules}, nil
+}
+
+func parseTagRule(line string) (tagRule, error) {
+	tag, s, found := strings.Cut(line, ":")
+	if !found {
+		return tagRule{}, fmt.Errorf("missing tag in %q", line)
+	}
+
+	tag = strings.TrimSpace(tag)
+	switch {
+	case tag == "":
+		return tagRule{}, fmt.Errorf("empty tag in %q", line)
+	case strings.ContainsAny(tag, `=<>!()"`):
+		return tagRule{}, fmt.Errorf("missing tag in %q", line)
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return tagRule{}, fmt.Errorf("empty rule for tag %q", tag)
+	}
+
+	expr, err := parseLine(s)
+	if err != nil {
+		return tagRule{}, err
+	}
+	return tagRule{tag: tag, expr: expr}, nil
+}
+
+// WithReadingSpeed sets reading speeds of the user, used by the readingtime
+// field to estimate the reading time of entries without it.
+func (self *Tagger) WithReadingSpeed(speed, cjkSpeed int) *Tagger {
+	for _, rule := range self.rules {
+		rule.expr.withReadingSpeed(speed, cjkSpeed)
+	}
+	return self
+}
+
+// Apply adds tags of all rules matching the entry, unless the entry already
+// has them. It returns true if any tag was added.
+func (self *Tagger) Apply(entry *model.Entry) bool {
+	var added bool
+	for _, rule := range self.rules {
+		hasTag := slices.ContainsFunc(entry.Tags, func(tag string) bool {
+			return strings.EqualFold(tag, rule.tag)
+		})
+		if hasTag || !rule.expr.Match(entry) {
+			continue
+		}
+		entry.Tags = append(entry.Tags, rule.tag)
+		added = true
+	}
+	return added
+}
@@ -0,0 +1,90 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestTagger(t *testing.T) {
+	tagger, err := NewTagger(`
+security: title=(?i)\b(cve|vulnerability)\b
+release: (title="(?i)\brelease\b" AND NOT tag="(?i)sponsored")
+Security: content=(?i)exploit
+`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		entry model.Entry
+		tags  []string
+	}{
+		{
+			name:  "no match",
+			entry: model.Entry{Title: "Weekly news"},
+		},
+		{
+			name:  "single rule",
+			entry: model.Entry{Title: "CVE-2026-0001 fixed"},
+			tags:  []string{"security"},
+		},
+		{
+			name:  "single rule alternative",
+			entry: model.Entry{Title: "Vulnerability disclosed"},
+			tags:  []string{"security"},
+		},
+		{
+			name: "expression",
+			entry: model.Entry{
+				Title: "Release 1.0",
+				Tags:  []string{"go"},
+			},
+			tags: []string{"go", "release"},
+		},
+		{
+			name: "expression excluded",
+			entry: model.Entry{
+				Title: "Release 1.0",
+				Tags:  []string{"Sponsored"},
+			},
+			tags: []string{"Sponsored"},
+		},
+		{
+			name: "tag added once",
+			entry: model.Entry{
+				Title:   "CVE-2026-0002",
+				Content: "An exploit is available",
+			},
+			tags: []string{"security"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := tt.entry
+			added := tagger.Apply(&entry)
+			assert.Equal(t, tt.tags, entry.Tags)
+			assert.Equal(t, len(tt.tags) > len(tt.entry.Tags), added)
+		})
+	}
+}
+
+func TestNewTagger_Error(t *testing.T) {
+	tests := []string{
+		"title=(?i)cve",
+		": title=(?i)cve",
+		"security:",
+		"security: title=[a-z",
+		`(title="a:b")`,
+	}
+
+	for _, s := range tests {
+		t.Run(s, func(t *testing.T) {
+			_, err := NewTagger(s)
+			require.Error(t, err)
+		})
+	}
+}
@@ -156,6 +156,7 @@ func (self *Create) createFeed(ctx context.Context,
 	feed.WithBlockMarkRead(r.BlockMarkRead)
 	feed.WithCategoryID(r.CategoryID)
 	feed.WithIgnoreEntryUpdates(r.IgnoreEntryUpdates)
+	feed.WithRefreshInterval(r.RefreshInterval)
 	feed.ContentChanged(body)
 	feed.CheckedNow()
 
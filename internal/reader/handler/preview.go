@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/storage"
+)
+
+// previewEntriesLimit is how many stored entries are previewed.
+const previewEntriesLimit = 100
+
+// PreviewFilter reports what filter rules of feed would do with its latest
+// stored entries and with the items it currently publishes, which may include
+// entries blocked by the previous rules. The feed is fetched, but nothing is
+// stored. Failing to fetch the feed isn't an error, it's reported in
+// [model.FilterPreview.FetchError] instead.
+func PreviewFilter(ctx context.Context, store *storage.Storage,
+	user *model.User, feed *model.Feed,
+) (*model.FilterPreview, error) {
+	stored, err := store.NewEntryQueryBuilder(user.ID).
+		WithFeedID(feed.ID).
+		WithoutStatus(model.EntryStatusRemoved).
+		WithContent(true).
+		WithSorting("published_at", "desc").
+		WithLimit(previewEntriesLimit).
+		GetEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reader/handler: get stored entries: %w", err)
+	}
+
+	hashes := make(map[string]struct{}, len(stored))
+	for _, e := range stored {
+		hashes[e.Hash] = struct{}{}
+	}
+
+	remote, fetchErr := fetchEntries(ctx, feed)
+	entries := make(model.Entries, 0, len(remote)+len(stored))
+	for _, e := range remote {
+		if _, found := hashes[e.Hash]; !found {
+			entries = append(entries, e)
+		}
+	}
+	entries = append(entries, stored...)
+
+	preview, err := filter.NewFeedFilter(user, feed).Preview(entries)
+	if err != nil {
+		return nil, err
+	} else if fetchErr != nil {
+		preview.FetchError = fetchErr.Error()
+	}
+	return preview, nil
+}
+
+func fetchEntries(ctx context.Context, feed *model.Feed) (model.Entries,
+	error,
+) {
+	resp, err := fetcher.NewRequestFeed(feed).Request(ctx, feed.FeedURL)
+	if err != nil {
+		return nil, fmt.Errorf("reader/handler: fetch feed: %w", err)
+	}
+	defer resp.Close()
+
+	if lerr := resp.LocalizedError(); lerr != nil {
+		return nil, lerr
+	}
+
+	body := newBodyBuffer()
+	defer body.Free()
+	if err := resp.WriteBodyTo(body.Buffer); err != nil {
+		return nil, fmt.Errorf("reader/handler: read feed body: %w", err)
+	}
+
+	remoteFeed, err := parser.ParseBytes(resp.EffectiveURL(), body.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("reader/handler: parse feed: %w", err)
+	}
+	return remoteFeed.Entries, nil
+}
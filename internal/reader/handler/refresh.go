@@ -13,6 +13,7 @@ import (
 	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/logging"
+	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/filter"
@@ -241,7 +242,7 @@ func (self *Refresh) refreshFeed(ctx context.Context, log *slog.Logger,
 		return nil, err
 	}
 
-	self.scheduleNextCheck(resp, remoteFeed, log)
+	self.feed.WithPollingHints(remoteFeed)
 	remoteEntriesLen := len(remoteFeed.Entries)
 	hashes, err := self.processEntries(ctx, remoteFeed.Entries)
 	if err != nil {
@@ -254,6 +255,10 @@ func (self *Refresh) refreshFeed(ctx context.Context, log *slog.Logger,
 	}
 	log.Debug("feed entries refreshed in storage")
 
+	// The check interval depends on the number of weekly entries, including
+	// the new ones.
+	self.scheduleNextCheck(ctx, resp, remoteFeed, log)
+
 	self.pushIntegrations(logging.WithLogger(ctx, log), refreshed.Created)
 
 	self.feed.EtagHeader = resp.ETag()
@@ -302,15 +307,23 @@ func (self *Refresh) parseFeed(feedURL string, body []byte, log *slog.Logger,
 	return feed, nil
 }
 
-func (self *Refresh) scheduleNextCheck(resp *fetcher.ResponseHandler,
-	remoteFeed *model.Feed, log *slog.Logger,
+func (self *Refresh) scheduleNextCheck(ctx context.Context,
+	resp *fetcher.ResponseHandler, remoteFeed *model.Feed, log *slog.Logger,
 ) {
+	if config.PollingScheduler() == config.SchedulerEntryFrequency {
+		self.updateCheckInterval(ctx, log)
+	}
+
 	// Use the RSS TTL value, or the Cache-Control or Expires HTTP headers if
 	// available. Otherwise, we use the default value from the configuration (min
-	// interval parameter).
-	ttl := remoteFeed.TTL
-	cacheControl := resp.CacheControlMaxAgeInMinutes()
-	expires := resp.ExpiresInMinutes()
+	// interval parameter). The refresh interval configured for this feed
+	// overrides all of them.
+	var ttl, cacheControl, expires int
+	if self.feed.RefreshInterval() == 0 {
+		ttl = remoteFeed.TTL
+		cacheControl = resp.CacheControlMaxAgeInMinutes()
+		expires = resp.ExpiresInMinutes()
+	}
 	refreshDelay := max(ttl, cacheControl, expires)
 
 	// Set the next check at with updated arguments.
@@ -322,10 +335,27 @@ func (self *Refresh) scheduleNextCheck(resp *fetcher.ResponseHandler,
 		slog.Int("cache_control_max_age_in_minutes", cacheControl),
 		slog.Int("expires_in_minutes", expires),
 		slog.Int("refresh_delay_in_minutes", refreshDelay),
+		slog.Int("update_period_in_minutes", self.feed.UpdatePeriod()),
+		slog.Any("skip_hours", self.feed.SkipHours()),
+		slog.Any("skip_days", self.feed.SkipDays()),
+		slog.Int("check_interval_in_minutes", self.feed.CheckInterval()),
+		slog.Int("feed_refresh_interval_in_minutes", self.feed.RefreshInterval()),
 		slog.Int("calculated_next_check_interval_in_minutes", nextCheck),
 		slog.Time("new_next_check_at", self.feed.NextCheckAt))
 }
 
+func (self *Refresh) updateCheckInterval(ctx context.Context,
+	log *slog.Logger,
+) {
+	n, err := self.store.WeeklyFeedEntryCount(ctx, self.userID, self.feedID)
+	if err != nil {
+		log.Error("Unable count weekly entries, keep previous check interval",
+			slog.Any("error", err))
+		return
+	}
+	self.feed.WithWeeklyEntryCount(n)
+}
+
 func (self *Refresh) processEntries(ctx context.Context, entries model.Entries,
 ) ([]string, error) {
 	self.feed.Entries = entries
@@ -368,6 +398,10 @@ func (self *Refresh) refreshFeedEntries(ctx context.Context,
 		return nil, fmt.Errorf("reader/handler: delete expired entries: %w", err)
 	}
 
+	if deleted != 0 && config.HasMetricsCollector() {
+		metric.ReclaimedEntries.WithLabelValues("delete").Add(float64(deleted))
+	}
+
 	refreshed.Deleted = deleted
 	return refreshed, nil
 }
@@ -53,6 +53,7 @@ func (h *Handler) Export(ctx context.Context, userID int64) (string, error) {
 			HideGlobally:                feed.HideGlobally,
 			AllowSelfSignedCertificates: feed.AllowSelfSignedCertificates,
 			DisableHTTP2:                feed.DisableHTTP2,
+			RefreshInterval:             feed.RefreshInterval(),
 		})
 	}
 
@@ -142,6 +143,7 @@ func applySubscriptionSettings(feed *model.Feed, s subcription) {
 	feed.HideGlobally = s.HideGlobally
 	feed.AllowSelfSignedCertificates = s.AllowSelfSignedCertificates
 	feed.DisableHTTP2 = s.DisableHTTP2
+	feed.WithRefreshInterval(s.RefreshInterval)
 }
 
 func validateSubscription(ctx context.Context, userID, categoryID int64,
@@ -165,6 +167,7 @@ func validateSubscription(ctx context.Context, userID, categoryID int64,
 		BlockFilterEntryRules:       s.BlockFilterEntryRules,
 		KeepFilterEntryRules:        s.KeepFilterEntryRules,
 		UrlRewriteRules:             s.UrlRewriteRules,
+		RefreshInterval:             s.RefreshInterval,
 	}
 
 	lerr := validator.ValidateFeedCreation(ctx, store, userID,
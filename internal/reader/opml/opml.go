@@ -53,6 +53,7 @@ type opmlOutline struct {
 	AllowSelfSignedCertificates bool   `xml:"miniflux:allowSelfSignedCertificates,attr,omitempty"`
 	DisableHTTP2                bool   `xml:"miniflux:disableHTTP2,attr,omitempty"`
 	IgnoreEntryUpdates          bool   `xml:"miniflux:ignoreEntryUpdates,attr,omitempty"`
+	RefreshInterval             int    `xml:"miniflux:refreshInterval,attr,omitempty"`
 }
 
 func (o opmlOutline) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -167,6 +168,8 @@ func (o *opmlOutline) setMinifluxAttribute(name, value string) error {
 		return setMinifluxBoolAttribute(name, value, &o.DisableHTTP2)
 	case "ignoreEntryUpdates":
 		return setMinifluxBoolAttribute(name, value, &o.IgnoreEntryUpdates)
+	case "refreshInterval":
+		return setMinifluxIntAttribute(name, value, &o.RefreshInterval)
 	}
 	return nil
 }
@@ -179,3 +182,12 @@ func setMinifluxBoolAttribute(name, value string, target *bool) error {
 	*target = parsedValue
 	return nil
 }
+
+func setMinifluxIntAttribute(name, value string, target *int) error {
+	parsedValue, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("opml: invalid miniflux attribute %q: %w", name, err)
+	}
+	*target = parsedValue
+	return nil
+}
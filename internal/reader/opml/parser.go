@@ -56,6 +56,7 @@ func getSubscriptionsFromOutlines(outlines opmlOutlineCollection, category strin
 				AllowSelfSignedCertificates: outline.AllowSelfSignedCertificates,
 				DisableHTTP2:                outline.DisableHTTP2,
 				IgnoreEntryUpdates:          outline.IgnoreEntryUpdates,
+				RefreshInterval:             outline.RefreshInterval,
 			})
 		} else if outline.Outlines.HasChildren() {
 			subscriptions = append(subscriptions, getSubscriptionsFromOutlines(outline.Outlines, outline.GetTitle())...)
@@ -305,6 +305,7 @@ func TestParseOpmlWithMinifluxSettings(t *testing.T) {
 					miniflux:allowSelfSignedCertificates="true"
 					miniflux:disableHTTP2="true"
 					miniflux:ignoreEntryUpdates="true"
+					miniflux:refreshInterval="5"
 				/>
 			</outline>
 		</body>
@@ -333,6 +334,7 @@ func TestParseOpmlWithMinifluxSettings(t *testing.T) {
 		AllowSelfSignedCertificates: true,
 		DisableHTTP2:                true,
 		IgnoreEntryUpdates:          true,
+		RefreshInterval:             5,
 	}
 
 	subscriptions, err := parse(bytes.NewBufferString(data))
@@ -74,6 +74,7 @@ func convertSubscriptionsToOPML(subscriptions []subcription) *opmlDocument {
 				AllowSelfSignedCertificates: subscription.AllowSelfSignedCertificates,
 				DisableHTTP2:                subscription.DisableHTTP2,
 				IgnoreEntryUpdates:          subscription.IgnoreEntryUpdates,
+				RefreshInterval:             subscription.RefreshInterval,
 			})
 		}
 
@@ -62,6 +62,7 @@ func TestSerializeWithMinifluxSettings(t *testing.T) {
 		AllowSelfSignedCertificates: true,
 		DisableHTTP2:                true,
 		IgnoreEntryUpdates:          true,
+		RefreshInterval:             1440,
 	}
 
 	output := serialize([]subcription{input})
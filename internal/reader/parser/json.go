@@ -291,6 +291,7 @@ func (self *jsonEntry) enclosures() []model.Enclosure {
 			URL:      att.URL,
 			MimeType: att.MimeType,
 			Size:     att.SizeInBytes,
+			Duration: att.DurationInSeconds,
 		}
 
 		if u, err := enc.ParsedURL(); err != nil {
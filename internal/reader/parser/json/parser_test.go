@@ -160,6 +160,10 @@ func TestParsePodcast(t *testing.T) {
 	if feed.Entries[0].Enclosures()[0].Size != 89970236 {
 		t.Errorf("Incorrect enclosure length, got: %d", feed.Entries[0].Enclosures()[0].Size)
 	}
+
+	if feed.Entries[0].Enclosures()[0].Duration != 6629 {
+		t.Errorf("Incorrect enclosure duration, got: %d", feed.Entries[0].Enclosures()[0].Duration)
+	}
 }
 
 func TestParseFeedWithFeedURLWithTrailingSpace(t *testing.T) {
@@ -20,6 +20,7 @@ type rssFeed struct {
 	baseURL *url.URL
 	rss     *rss.Feed
 	feed    *model.Feed
+	sy      syndication
 }
 
 func parseRSS(feedURL *url.URL, b []byte) (*model.Feed, error) {
@@ -29,7 +30,7 @@ func parseRSS(feedURL *url.URL, b []byte) (*model.Feed, error) {
 		return nil, fmt.Errorf("reader/parser: parse RSS feed: %w", err)
 	}
 
-	var p rssFeed
+	p := rssFeed{sy: parseSyndication(b)}
 	return p.Feed(feedURL, parsed)
 }
 
@@ -44,6 +45,9 @@ func (self *rssFeed) Feed(feedURL *url.URL, rssFeed *rss.Feed,
 	}
 
 	self.feed.WithLanguage(self.language())
+	self.feed.WithSkipHours(self.skipHours()).
+		WithSkipDays(self.skipDays()).
+		WithUpdatePeriod(self.updatePeriod())
 	self.feed.WithFeedURL(self.feedURL())
 	self.feed.WithSiteURL(self.siteURL())
 	self.feed.IconURL = self.iconURL()
@@ -60,6 +64,70 @@ func (self *rssFeed) language() string {
 	return self.rss.DublinCoreExt.Language
 }
 
+func (self *rssFeed) skipHours() []int {
+	if len(self.rss.SkipHours) == 0 {
+		return nil
+	}
+
+	hours := make([]int, 0, len(self.rss.SkipHours))
+	for _, s := range self.rss.SkipHours {
+		if h, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			// Some publishers use 1-24 instead of 0-23.
+			hours = append(hours, h%24)
+		}
+	}
+	return hours
+}
+
+func (self *rssFeed) skipDays() []time.Weekday {
+	if len(self.rss.SkipDays) == 0 {
+		return nil
+	}
+
+	days := make([]time.Weekday, 0, len(self.rss.SkipDays))
+	for _, s := range self.rss.SkipDays {
+		if d, ok := weekdays[strings.ToLower(strings.TrimSpace(s))]; ok {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// updatePeriod returns update period in minutes, declared by syndication
+// module.
+func (self *rssFeed) updatePeriod() int {
+	period, ok := updatePeriods[strings.ToLower(self.sy.UpdatePeriod)]
+	if !ok {
+		return 0
+	}
+
+	frequency := 1
+	if s := self.sy.UpdateFrequency; s != "" {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			frequency = n
+		}
+	}
+	return period / frequency
+}
+
+var updatePeriods = map[string]int{
+	"hourly":  60,
+	"daily":   24 * 60,
+	"weekly":  7 * 24 * 60,
+	"monthly": 30 * 24 * 60,
+	"yearly":  365 * 24 * 60,
+}
+
 func (self *rssFeed) feedURL() *url.URL {
 	link := self.rss.FeedLink()
 	if link == "" {
@@ -257,6 +325,11 @@ func (self *rssEntry) commentsURL() string {
 }
 
 func (self *rssEntry) readingTime() int {
+	return self.duration() / 60
+}
+
+// duration returns itunes:duration of the item in seconds.
+func (self *rssEntry) duration() int {
 	if self.rss.ITunesExt == nil || self.rss.ITunesExt.Duration == "" {
 		return 0
 	}
@@ -276,10 +349,13 @@ func (self *rssEntry) readingTime() int {
 		seconds += int(math.Pow(60, float64(n-i))) * v
 		i++
 	}
-	return seconds / 60
+	return seconds
 }
 
 func (self *rssEntry) enclosures() (enclosures []model.Enclosure) {
+	// itunes:duration is the duration of the episode, which is the enclosure
+	// of the item.
+	duration := int64(self.duration())
 	for rssEnc := range self.rss.AllEnclosures() {
 		enc := model.Enclosure{URL: rssEnc.URL, MimeType: rssEnc.Type}
 		if u, err := enc.ParsedURL(); err != nil {
@@ -293,6 +369,10 @@ func (self *rssEntry) enclosures() (enclosures []model.Enclosure) {
 				enc.Size = size
 			}
 		}
+
+		if enc.IsAudio() || enc.IsVideo() {
+			enc.Duration = duration
+		}
 		enclosures = append(enclosures, enc)
 	}
 	return enclosures
@@ -1501,6 +1501,38 @@ func TestParseItunesDuration(t *testing.T) {
 	}
 }
 
+func TestParseItunesDurationOfEnclosure(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+		<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+		<channel>
+			<title>Podcast Example</title>
+			<link>http://www.example.com/index.html</link>
+			<item>
+				<title>Podcast Episode</title>
+				<guid>http://example.com/episode.m4a</guid>
+				<enclosure url="http://example.com/episode.m4a" length="1000" type="audio/x-m4a" />
+				<itunes:duration>1:23:45</itunes:duration>
+			</item>
+			<item>
+				<title>Cover</title>
+				<guid>http://example.com/cover.jpg</guid>
+				<enclosure url="http://example.com/cover.jpg" length="100" type="image/jpeg" />
+				<itunes:duration>1:23:45</itunes:duration>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := parser.ParseBytes("https://example.org/", []byte(data))
+	require.NoError(t, err)
+	require.Len(t, feed.Entries, 2)
+
+	require.Len(t, feed.Entries[0].Enclosures(), 1)
+	assert.Equal(t, int64(5025), feed.Entries[0].Enclosures()[0].Duration)
+
+	require.Len(t, feed.Entries[1].Enclosures(), 1)
+	assert.Zero(t, feed.Entries[1].Enclosures()[0].Duration)
+}
+
 func TestParseIncorrectItunesDuration(t *testing.T) {
 	data := `<?xml version="1.0" encoding="UTF-8"?>
 		<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
@@ -1796,6 +1828,117 @@ func TestParseFeedWithIncorrectTTLValue(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithSkipHoursAndDays(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<skipHours>
+				<hour>0</hour>
+				<hour>1</hour>
+				<hour>24</hour>
+				<hour>invalid</hour>
+			</skipHours>
+			<skipDays>
+				<day>Saturday</day>
+				<day>sunday</day>
+				<day>Someday</day>
+			</skipDays>
+			<item>
+				<title>Test</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := parser.ParseBytes("https://example.org/", []byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, feed.SkipHours())
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Saturday}, feed.SkipDays())
+	assert.Zero(t, feed.UpdatePeriod())
+}
+
+func TestParseFeedWithSyndicationModule(t *testing.T) {
+	tests := []struct {
+		name      string
+		period    string
+		frequency string
+		expected  int
+	}{
+		{
+			name:     "hourly",
+			period:   "hourly",
+			expected: 60,
+		},
+		{
+			name:      "twice a day",
+			period:    "daily",
+			frequency: "2",
+			expected:  12 * 60,
+		},
+		{
+			name:      "invalid frequency",
+			period:    "Weekly",
+			frequency: "invalid",
+			expected:  7 * 24 * 60,
+		},
+		{
+			name:   "invalid period",
+			period: "sometimes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `<?xml version="1.0" encoding="utf-8"?>
+				<rss version="2.0" xmlns:syn="http://purl.org/rss/1.0/modules/syndication/">
+				<channel>
+					<title>Example</title>
+					<link>https://example.org/</link>
+					<syn:updatePeriod>` + tt.period + `</syn:updatePeriod>
+					<syn:updateFrequency>` + tt.frequency + `</syn:updateFrequency>
+					<item>
+						<title>Test</title>
+						<link>https://example.org/item</link>
+					</item>
+				</channel>
+				</rss>`
+
+			feed, err := parser.ParseBytes("https://example.org/", []byte(data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, feed.UpdatePeriod())
+			require.Len(t, feed.Entries, 1)
+		})
+	}
+}
+
+func TestParseRDFWithSyndicationModule(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns="http://purl.org/rss/1.0/"
+			xmlns:sy="http://purl.org/rss/1.0/modules/syndication/">
+		<channel rdf:about="https://example.org/">
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<items>
+				<rdf:Seq><rdf:li rdf:resource="https://example.org/item"/></rdf:Seq>
+			</items>
+			<sy:updatePeriod>daily</sy:updatePeriod>
+			<sy:updateFrequency>4</sy:updateFrequency>
+		</channel>
+		<item rdf:about="https://example.org/item">
+			<title>Test</title>
+			<link>https://example.org/item</link>
+		</item>
+		</rdf:RDF>`
+
+	feed, err := parser.ParseBytes("https://example.org/", []byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, 6*60, feed.UpdatePeriod())
+	require.Len(t, feed.Entries, 1)
+}
+
 func TestParseEntriesWithDuplicateGUIDAndDistinctLinks(t *testing.T) {
 	// Some non-conformant feeds (e.g. fluentboards.com) ship the same <guid>
 	// for every item. The first occurrence must keep the historical
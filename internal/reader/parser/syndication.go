@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// syndicationNamespace is the namespace of RSS syndication module.
+const syndicationNamespace = "http://purl.org/rss/1.0/modules/syndication/"
+
+// syndication is the update schedule of the channel, declared by
+// sy:updatePeriod and sy:updateFrequency of RSS syndication module.
+type syndication struct {
+	UpdatePeriod    string
+	UpdateFrequency string
+}
+
+// parseSyndication decodes elements of syndication module from the RSS feed
+// b. The channel declares them before its items, so it stops at the first
+// item. Malformed feeds return what has been decoded before the error. Feeds,
+// which don't declare the namespace, aren't decoded again.
+func parseSyndication(b []byte) (sy syndication) {
+	if !bytes.Contains(b, []byte(syndicationNamespace)) {
+		return sy
+	}
+
+	d := xml.NewDecoder(bytes.NewReader(b))
+	d.Entity = xml.HTMLEntity
+	d.Strict = false
+	d.CharsetReader = charset.NewReaderLabel
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return sy
+		}
+
+		start, ok := tok.(xml.StartElement)
+		switch {
+		case !ok:
+			continue
+		case strings.EqualFold(start.Name.Local, "item"):
+			return sy
+		case start.Name.Space != syndicationNamespace:
+			continue
+		}
+
+		var value string
+		if err := d.DecodeElement(&value, &start); err != nil {
+			return sy
+		}
+
+		switch start.Name.Local {
+		case "updatePeriod":
+			sy.UpdatePeriod = strings.TrimSpace(value)
+		case "updateFrequency":
+			sy.UpdateFrequency = strings.TrimSpace(value)
+		}
+	}
+}
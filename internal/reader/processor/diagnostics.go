@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/storage"
+)
+
+const (
+	// minScrapedTextRatio is the minimal length of scraped text, relative to
+	// the text of the feed content. Shorter scraped content is likely a
+	// truncated article or not the article at all, so the feed content is kept.
+	minScrapedTextRatio = 0.5
+
+	// previewLength is the length of the text preview of scraped content in
+	// runes.
+	previewLength = 500
+)
+
+// DiagnoseScraper scrapes the web page of entry like [ProcessEntryWebPage] and
+// reports how its content was extracted. Neither feed nor entry are changed.
+// Failing to scrape the web page isn't an error, it's reported in
+// [model.ScraperDiagnostics.Error] instead.
+func DiagnoseScraper(ctx context.Context, store *storage.Storage,
+	feed *model.Feed, entry *model.Entry, user *model.User,
+) (*model.ScraperDiagnostics, error) {
+	siteRules, err := store.SiteRules(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("reader/processor: fetch site rules: %w", err)
+	}
+
+	e := *entry
+	removeTracking(&e, feed.Hostnames()...)
+	rewrite.RewriteEntryURL(ctx, feed, &e)
+
+	d := &model.ScraperDiagnostics{
+		EntryID:            e.ID,
+		URL:                e.URL,
+		OriginalTextLength: textLength(e.Content),
+	}
+
+	p := FeedProcessor{store: store, feed: feed, user: user,
+		siteRules: siteRules}
+	d.Rendered = p.renderPages(&e)
+	result, site, err := p.scrape(ctx, &e)
+	if err != nil {
+		d.Error = err.Error()
+		return d, nil
+	}
+
+	switch {
+	case result.Rules == "":
+	case feed.ScraperRules != "":
+		d.RulesSource = model.ScraperRulesFeed
+	case site != nil && site.ScraperRules != "":
+		d.RulesSource = model.ScraperRulesSite
+		d.SiteRuleID = site.ID
+	default:
+		d.RulesSource = model.ScraperRulesPredefined
+	}
+
+	text := contentText(result.Content)
+	d.FinalURL = result.URL
+	d.Method = result.Method
+	d.Rules = result.Rules
+	d.RulesError = result.RulesError
+	d.Pages = result.Pages
+	d.HTMLSize = result.HTMLSize
+	d.ContentSize = int64(len(result.Content))
+	d.TextLength = utf8.RuneCountInString(text)
+	d.Fallback = d.OriginalTextLength > 0 &&
+		(result.Content == "" || scrapedTooShort(e.Content, result.Content))
+	d.Preview = preview(text)
+	return d, nil
+}
+
+// scrapedTooShort returns true if the text of scraped content is much shorter
+// than the text of the feed content.
+func scrapedTooShort(original, scraped string) bool {
+	originalLength := textLength(original)
+	if originalLength == 0 {
+		return false
+	}
+	return float64(textLength(scraped)) <
+		float64(originalLength)*minScrapedTextRatio
+}
+
+// contentText returns the text of HTML content with collapsed white space.
+func contentText(content string) string {
+	text := html.UnescapeString(sanitizer.StripTags(content))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func textLength(content string) int {
+	return utf8.RuneCountInString(contentText(content))
+}
+
+func preview(text string) string {
+	if utf8.RuneCountInString(text) <= previewLength {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:previewLength])) + "…"
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrapedTooShort(t *testing.T) {
+	article := "<p>" + strings.Repeat("word ", 100) + "</p>"
+	tests := []struct {
+		name     string
+		original string
+		scraped  string
+		want     bool
+	}{
+		{name: "no feed content", scraped: "<p>Short</p>"},
+		{name: "summary", original: "<p>Summary</p>", scraped: article},
+		{name: "same length", original: article, scraped: article},
+		{
+			name:     "markup doesn't count",
+			original: article,
+			scraped:  `<div class="a"><p>` + strings.Repeat("word ", 60) + "</p></div>",
+		},
+		{
+			name:     "much shorter",
+			original: article,
+			scraped:  `<p>Subscribe to continue reading &amp; more</p>`,
+			want:     true,
+		},
+		{name: "empty", original: article, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, scrapedTooShort(tt.original, tt.scraped))
+		})
+	}
+}
+
+func TestContentText(t *testing.T) {
+	assert.Equal(t, "Title & text next",
+		contentText("<h1>Title &amp; text</h1>\n\n <p>next</p>"))
+	assert.Empty(t, contentText("<img src=\"a.jpg\">"))
+}
+
+func TestPreview(t *testing.T) {
+	assert.Equal(t, "short", preview("short"))
+
+	got := preview(strings.Repeat("é", previewLength+10))
+	assert.Equal(t, previewLength+1, utf8.RuneCountInString(got))
+	assert.True(t, strings.HasSuffix(got, "…"))
+}
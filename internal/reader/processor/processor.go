@@ -36,10 +36,11 @@ func UpdateEntry(user *model.User, entry *model.Entry) error {
 }
 
 type FeedProcessor struct {
-	store *storage.Storage
-	feed  *model.Feed
-	user  *model.User
-	force bool
+	store     *storage.Storage
+	feed      *model.Feed
+	user      *model.User
+	siteRules model.SiteRules
+	force     bool
 
 	skipAgeFilter bool
 	userByIDFunc  storage.UserByIDFunc
@@ -99,7 +100,13 @@ func (self *FeedProcessor) init(ctx context.Context, userID int64, force bool,
 		return fmt.Errorf("reader/processor: fetch user id=%v: %w", userID, err)
 	}
 
-	self.user, self.force = user, force
+	siteRules, err := self.store.SiteRules(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("reader/processor: fetch site rules of user id=%v: %w",
+			userID, err)
+	}
+
+	self.user, self.siteRules, self.force = user, siteRules, force
 	return nil
 }
 
@@ -110,7 +117,14 @@ func (self *FeedProcessor) process(ctx context.Context) error {
 	if err != nil {
 		log.Debug("entries filter completed with error", slog.Any("error", err))
 		return fmt.Errorf("%w: delete filtered entries: %w", ErrBadFeed, err)
-	} else if len(self.feed.Entries) == 0 {
+	}
+
+	err = filter.StoreHits(ctx, self.store, self.user.ID, self.feed)
+	if err != nil {
+		log.Error("unable store filter rule hits", slog.Any("error", err))
+	}
+
+	if len(self.feed.Entries) == 0 {
 		log.Debug("all entries deleted, nothing left")
 		return nil
 	}
@@ -132,7 +146,16 @@ func (self *FeedProcessor) process(ctx context.Context) error {
 	}
 
 	contentRewrite := rewrite.NewContentRewrite(self.feed.RewriteRules,
-		self.user, self.templates)
+		self.user, self.templates).WithSiteRules(self.siteRules)
+
+	tagger, err := filter.NewTagger(self.user.TagEntryRules())
+	if err != nil {
+		log.Error("unable parse tag rules", slog.Any("error", err))
+		tagger = nil
+	} else {
+		tagger.WithReadingSpeed(self.user.DefaultReadingSpeed,
+			self.user.CJKReadingSpeed)
+	}
 
 	for _, entry := range self.feed.Entries {
 		log := log.With(
@@ -148,14 +171,16 @@ func (self *FeedProcessor) process(ctx context.Context) error {
 		log.Debug("Processing entry")
 
 		removeTracking(entry, self.feed.Hostnames()...)
-		rewrite.RewriteEntryURL(ctx, self.feed, entry)
+		// Stored entries aren't updated, so don't download their pages again.
+		rewrite.RewriteEntryURL(ctx, self.feed, entry,
+			rewrite.WithoutFetch(!self.force && entry.Stored()))
 
 		var pageURL string
 		if self.feed.Crawler && (self.force || !entry.Stored()) {
 			log := log.With(slog.Bool("force_refresh", self.force))
 			log.Debug("Scraping entry")
 
-			scrapedURL, _, err := self.Scrape(ctx, entry)
+			scrapedURL, err := self.crawl(ctx, entry)
 			if err != nil {
 				log.Warn("Unable scrape entry", slog.Any("error", err))
 				return fmt.Errorf("%w: scrape entry: %w", ErrBadFeed, err)
@@ -174,6 +199,10 @@ func (self *FeedProcessor) process(ctx context.Context) error {
 		}
 		updateEntryReadingTime(ctx, self.store, self.feed, entry, !entry.Stored(),
 			self.user)
+
+		if tagger != nil && tagger.Apply(entry) {
+			log.Debug("Tagged entry", slog.Any("tags", entry.Tags))
+		}
 	}
 
 	if self.user.ShowReadingTime && shouldFetchYouTubeWatchTimeInBulk() {
@@ -228,14 +257,68 @@ func removeTracking(entry *model.Entry, hostnames ...string) {
 
 func (self *FeedProcessor) Scrape(ctx context.Context, entry *model.Entry,
 ) (string, string, error) {
+	result, _, err := self.scrape(ctx, entry)
+	if err != nil {
+		return "", "", err
+	} else if result.Content != "" {
+		// We replace the entry content only if the scraper doesn't return any
+		// error.
+		entry.Content = result.Content
+	}
+	return result.BaseURL, result.Content, nil
+}
+
+// crawl replaces the content of entry by the scraped content, like Scrape,
+// for the crawler of the feed. Unlike a download requested by the user, the
+// feed content is kept if the scraped content is much shorter.
+func (self *FeedProcessor) crawl(ctx context.Context, entry *model.Entry,
+) (string, error) {
+	result, _, err := self.scrape(ctx, entry)
+	if err != nil {
+		return "", err
+	} else if result.Content == "" {
+		return result.BaseURL, nil
+	} else if scrapedTooShort(entry.Content, result.Content) {
+		logging.FromContext(ctx).Info(
+			"Keep feed content, scraped content is much shorter",
+			slog.String("url", entry.URL),
+			slog.String("scraper_method", result.Method))
+		return "", nil
+	}
+
+	entry.Content = result.Content
+	return result.BaseURL, nil
+}
+
+// scrape fetches the original content of entry and returns it with the
+// matching site rule, if any.
+func (self *FeedProcessor) scrape(ctx context.Context, entry *model.Entry,
+) (*scraper.Result, *model.SiteRule, error) {
 	startTime := time.Now()
 	builder := fetcher.NewRequestFeed(self.feed)
+	rules := self.feed.ScraperRules
+	log := logging.FromContext(ctx).With(slog.String("url", entry.URL))
 
-	logging.FromContext(ctx).Info("Fetch original content",
-		slog.String("url", entry.URL))
+	if self.renderPages(entry) {
+		builder.WithRenderService()
+		log = log.With(slog.Bool("render", true))
+	}
+
+	site := self.siteRules.Match(entry.Hostname())
+	if site != nil {
+		log = log.With(slog.Int64("site_rule_id", site.ID),
+			slog.String("site_rule_hostname", site.Hostname))
+		builder.WithUserAgent(site.UserAgent)
+		if site.Referer != "" {
+			builder.WithHeader("Referer", site.Referer)
+		}
+		if rules == "" {
+			rules = site.ScraperRules
+		}
+	}
+	log.Info("Fetch original content")
 
-	baseURL, content, err := scraper.ScrapeWebsite(ctx, builder, entry.URL,
-		self.feed.ScraperRules)
+	result, err := scraper.ScrapeWebsite(ctx, builder, entry.URL, rules)
 	if config.HasMetricsCollector() {
 		status := metric.StatusSuccess
 		if err != nil {
@@ -247,13 +330,18 @@ func (self *FeedProcessor) Scrape(ctx context.Context, entry *model.Entry,
 	}
 
 	if err != nil {
-		return "", "", err
-	} else if content != "" {
-		// We replace the entry content only if the scraper doesn't return any
-		// error.
-		entry.Content = content
+		return nil, site, err
+	}
+	return result, site, nil
+}
+
+// renderPages returns true if the web page of entry is scraped through the
+// render service, enabled by the feed or configured for its domain.
+func (self *FeedProcessor) renderPages(entry *model.Entry) bool {
+	if config.RenderServiceURL() == nil {
+		return false
 	}
-	return baseURL, content, nil
+	return self.feed.RenderPages() || config.RenderServiceDomain(entry.Hostname())
 }
 
 func (self *FeedProcessor) UpdateEntry(entry *model.Entry, pageURL string,
@@ -325,13 +413,20 @@ func makeTitle(content string) string {
 }
 
 // ProcessEntryWebPage downloads the entry web page and apply rewrite rules.
-func ProcessEntryWebPage(ctx context.Context, feed *model.Feed,
-	entry *model.Entry, user *model.User, opts ...sanitizer.Option,
+func ProcessEntryWebPage(ctx context.Context, store *storage.Storage,
+	feed *model.Feed, entry *model.Entry, user *model.User,
+	opts ...sanitizer.Option,
 ) error {
 	removeTracking(entry, feed.Hostnames()...)
 	rewrite.RewriteEntryURL(ctx, feed, entry)
 
-	p := FeedProcessor{feed: feed, user: user}
+	siteRules, err := store.SiteRules(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("reader/processor: fetch site rules: %w", err)
+	}
+
+	p := FeedProcessor{store: store, feed: feed, user: user,
+		siteRules: siteRules}
 	pageURL, content, err := p.Scrape(ctx, entry)
 	if err != nil || content == "" {
 		return err
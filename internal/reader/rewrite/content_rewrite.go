@@ -7,9 +7,7 @@ import (
 	"context"
 	"html"
 	"log/slog"
-	"strconv"
 	"strings"
-	"text/scanner"
 
 	"miniflux.app/v2/internal/logging"
 	"miniflux.app/v2/internal/model"
@@ -18,6 +16,7 @@ import (
 
 type ContentRewrite struct {
 	rules     []rule
+	siteRules model.SiteRules
 	templates *template.Engine
 	user      *model.User
 }
@@ -27,32 +26,26 @@ func NewContentRewrite(userRules string, u *model.User, t *template.Engine,
 	return &ContentRewrite{rules: parseRules(userRules), user: u, templates: t}
 }
 
-func parseRules(s string) []rule {
-	scan := scanner.Scanner{Mode: scanner.ScanIdents | scanner.ScanStrings}
-	scan.Init(strings.NewReader(s))
+// ValidateContentRewriteRules returns an error if the content rewrite rules
+// can't be parsed.
+func ValidateContentRewriteRules(s string) error {
+	_, err := readRules(s)
+	return err
+}
 
-	var rules []rule
-	for {
-		switch scan.Scan() {
-		case scanner.Ident:
-			rules = append(rules, rule{name: scan.TokenText()})
-		case scanner.String:
-			if len(rules) == 0 {
-				continue
-			}
-			s, _ := strconv.Unquote(scan.TokenText())
-			last := len(rules) - 1
-			rules[last].args = append(rules[last].args, s)
-		case scanner.EOF:
-			return rules
-		}
-	}
+// WithSiteRules sets site rules of the user. Rewrite rules of the site rule
+// matching the entry are used, when the feed has no rewrite rules, instead of
+// predefined ones.
+func (self *ContentRewrite) WithSiteRules(rules model.SiteRules,
+) *ContentRewrite {
+	self.siteRules = rules
+	return self
 }
 
 func (self *ContentRewrite) Apply(ctx context.Context, entry *model.Entry) {
 	rules := self.rules
 	if len(rules) == 0 {
-		rules = findDomainRule(entry.Hostname())
+		rules = self.domainRules(entry.Hostname())
 	}
 
 	logging.FromContext(ctx).Debug("Applying content rewrite rules",
@@ -64,6 +57,14 @@ func (self *ContentRewrite) Apply(ctx context.Context, entry *model.Entry) {
 	entry.Content = addPDFLink(entry.URL, entry.Content)
 }
 
+func (self *ContentRewrite) domainRules(hostname string) []rule {
+	site := self.siteRules.Match(hostname)
+	if site != nil && site.RewriteRules != "" {
+		return parseRules(site.RewriteRules)
+	}
+	return findDomainRule(hostname)
+}
+
 func (self *ContentRewrite) applyRule(ctx context.Context, entry *model.Entry,
 	r rule,
 ) {
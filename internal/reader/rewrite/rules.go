@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"text/scanner"
 
 	"miniflux.app/v2/internal/model"
 )
@@ -16,6 +17,55 @@ type rule struct {
 
 var _ fmt.Stringer = (*rule)(nil)
 
+// parseRules parses a list of rules like
+// name1,name2("arg1"|"arg2"), separated by commas or new lines. Invalid rules
+// are skipped.
+func parseRules(s string) []rule {
+	rules, _ := readRules(s)
+	return rules
+}
+
+// readRules parses rules like parseRules and returns the first syntax error
+// too. Arguments are Go strings, double quoted or raw in backquotes.
+func readRules(s string) ([]rule, error) {
+	var err error
+	scan := scanner.Scanner{
+		Mode: scanner.ScanIdents | scanner.ScanStrings | scanner.ScanRawStrings,
+	}
+	scan.Init(strings.NewReader(s))
+	scan.Error = func(s *scanner.Scanner, msg string) {
+		if err == nil {
+			err = fmt.Errorf("%s: %s", s.Pos(), msg)
+		}
+	}
+
+	var rules []rule
+	for {
+		switch scan.Scan() {
+		case scanner.Ident:
+			rules = append(rules, rule{name: scan.TokenText()})
+		case scanner.String, scanner.RawString:
+			if len(rules) == 0 {
+				if err == nil {
+					err = fmt.Errorf("%s: argument %s without rule", scan.Position,
+						scan.TokenText())
+				}
+				continue
+			}
+
+			arg, uerr := strconv.Unquote(scan.TokenText())
+			if uerr != nil && err == nil {
+				err = fmt.Errorf("%s: invalid argument %s: %w", scan.Position,
+					scan.TokenText(), uerr)
+			}
+			last := len(rules) - 1
+			rules[last].args = append(rules[last].args, arg)
+		case scanner.EOF:
+			return rules, err
+		}
+	}
+}
+
 func (self *rule) String() string {
 	if len(self.args) == 0 {
 		return self.name
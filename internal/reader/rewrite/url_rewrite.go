@@ -5,52 +5,166 @@ package rewrite // import "miniflux.app/v2/internal/reader/rewrite"
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/url"
+	"path"
 	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
 
 	"miniflux.app/v2/internal/logging"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
 )
 
+// customReplaceRuleRegex matches the single rewrite rule, which was the only
+// supported format of URL rewrite rules. Its regular expression isn't a Go
+// string, like `\.` instead of `\\.`, so it's matched before parsing.
 var customReplaceRuleRegex = regexp.MustCompile(
 	`^rewrite\("([^"]+)"\|"([^"]+)"\)$`)
 
+// List of URL rewrite rules:
+//
+//   - rewrite("regex"|"replacement") replaces the URL matched by regex.
+//   - strip_query removes all query parameters, strip_query("utm_*"|"ref")
+//     removes parameters matching given patterns.
+//   - force_https replaces the http scheme with https.
+//   - amp_to_canonical replaces URLs of AMP pages by URLs of their canonical
+//     pages.
+//   - follow_canonical downloads the page and uses the URL of its canonical
+//     link or the URL after redirects.
+//
+// Rules are separated by commas or new lines and applied in order.
+var urlRewriteRules = map[string]struct {
+	minArgs, maxArgs int
+	// fetch is true for rules, which download the page.
+	fetch bool
+}{
+	"rewrite":          {2, 2, false},
+	"strip_query":      {0, -1, false},
+	"force_https":      {0, 0, false},
+	"amp_to_canonical": {0, 0, false},
+	"follow_canonical": {0, 0, true},
+}
+
+// URLOption configures RewriteEntryURL.
+type URLOption func(c *urlConfig)
+
+type urlConfig struct {
+	skipFetch bool
+}
+
+// WithoutFetch skips rules, which download the page, like follow_canonical,
+// when value is true.
+func WithoutFetch(value bool) URLOption {
+	return func(c *urlConfig) { c.skipFetch = value }
+}
+
+// ValidateURLRewriteRules returns an error if the URL rewrite rules can't be
+// parsed or applied.
+func ValidateURLRewriteRules(s string) error {
+	_, err := parseURLRewriteRules(s)
+	return err
+}
+
+func parseURLRewriteRules(s string) ([]rule, error) {
+	var rules []rule
+	s = strings.TrimSpace(s)
+	if parts := customReplaceRuleRegex.FindStringSubmatch(s); parts != nil {
+		rules = []rule{{name: "rewrite", args: parts[1:]}}
+	} else if parsed, err := readRules(s); err != nil {
+		return nil, err
+	} else {
+		rules = parsed
+	}
+
+	for _, r := range rules {
+		if err := validateURLRewriteRule(&r); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", &r, err)
+		}
+	}
+	return rules, nil
+}
+
+func validateURLRewriteRule(r *rule) error {
+	spec, ok := urlRewriteRules[r.name]
+	switch {
+	case !ok:
+		return errors.New("unknown rule")
+	case len(r.args) < spec.minArgs:
+		return fmt.Errorf("expected %d arguments", spec.minArgs)
+	case spec.maxArgs >= 0 && len(r.args) > spec.maxArgs:
+		return fmt.Errorf("expected at most %d arguments", spec.maxArgs)
+	}
+
+	switch r.name {
+	case "rewrite":
+		if _, err := regexp.Compile(r.args[0]); err != nil {
+			return err
+		} else if r.args[1] == "" {
+			return errors.New("empty replacement")
+		}
+	case "strip_query":
+		for _, pattern := range r.args {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RewriteEntryURL applies URL rewrite rules of the feed to the entry URL.
+// Invalid rules are logged and ignored.
 func RewriteEntryURL(ctx context.Context, feed *model.Feed, entry *model.Entry,
+	opts ...URLOption,
 ) {
 	if feed.UrlRewriteRules == "" {
 		return
 	}
 
-	log := logging.FromContext(ctx)
-	parts := customReplaceRuleRegex.FindStringSubmatch(feed.UrlRewriteRules)
-	if len(parts) < 3 {
-		log.Debug("Cannot find search and replace terms for replace rule",
-			slog.String("entry_url", entry.URL),
-			slog.Int64("feed_id", feed.ID),
-			slog.String("feed_url", feed.FeedURL),
-			slog.String("url_rewrite_rules", feed.UrlRewriteRules))
-		return
+	var c urlConfig
+	for _, opt := range opts {
+		opt(&c)
 	}
 
-	re, err := regexp.Compile(parts[1])
+	log := logging.FromContext(ctx).With(
+		slog.String("entry_url", entry.URL),
+		slog.Int64("feed_id", feed.ID),
+		slog.String("feed_url", feed.FeedURL),
+		slog.String("url_rewrite_rules", feed.UrlRewriteRules))
+
+	rules, err := parseURLRewriteRules(feed.UrlRewriteRules)
 	if err != nil {
-		log.Error("Failed on regexp compilation",
-			slog.String("url_rewrite_rules", feed.UrlRewriteRules),
-			slog.Any("error", err))
+		log.Debug("Invalid URL rewrite rules", slog.Any("error", err))
 		return
 	}
 
-	rewrittenURL := re.ReplaceAllString(entry.URL, parts[2])
+	rewrittenURL := entry.URL
+	for _, r := range rules {
+		if c.skipFetch && urlRewriteRules[r.name].fetch {
+			continue
+		}
+
+		s, err := applyURLRewriteRule(ctx, feed, &r, rewrittenURL)
+		if err != nil {
+			log.Warn("Unable apply URL rewrite rule",
+				slog.String("rule", r.String()),
+				slog.Any("error", err))
+			continue
+		}
+		rewrittenURL = s
+	}
+
 	if rewrittenURL == entry.URL {
 		return
 	}
 
 	log.Debug("Rewriting entry URL",
-		slog.String("original_entry_url", entry.URL),
-		slog.String("rewritten_entry_url", rewrittenURL),
-		slog.Int64("feed_id", feed.ID),
-		slog.String("feed_url", feed.FeedURL))
+		slog.String("rewritten_entry_url", rewrittenURL))
 
 	u, err := url.Parse(rewrittenURL)
 	if err != nil {
@@ -61,3 +175,168 @@ func RewriteEntryURL(ctx context.Context, feed *model.Feed, entry *model.Entry,
 	}
 	entry.WithURL(u)
 }
+
+func applyURLRewriteRule(ctx context.Context, feed *model.Feed, r *rule,
+	pageURL string,
+) (string, error) {
+	if r.name == "rewrite" {
+		re, err := regexp.Compile(r.args[0])
+		if err != nil {
+			return "", err
+		}
+		return re.ReplaceAllString(pageURL, r.args[1]), nil
+	}
+
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch r.name {
+	case "strip_query":
+		stripQuery(u, r.args)
+	case "force_https":
+		if u.Scheme == "http" {
+			u.Scheme = "https"
+		}
+	case "amp_to_canonical":
+		u = ampToCanonical(u)
+	case "follow_canonical":
+		return followCanonical(ctx, fetcher.NewRequestFeed(feed), pageURL)
+	}
+	return u.String(), nil
+}
+
+// stripQuery removes query parameters matching any of patterns or all of them
+// without patterns.
+func stripQuery(u *url.URL, patterns []string) {
+	if len(patterns) == 0 {
+		u.RawQuery = ""
+		return
+	}
+
+	query := u.Query()
+	for name := range query {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				query.Del(name)
+				break
+			}
+		}
+	}
+	u.RawQuery = query.Encode()
+}
+
+// ampToCanonical returns the URL of the canonical page of an AMP page, served
+// by AMP caches or by the site itself, like amp.example.com/article,
+// /article.amp.html or /article/amp?amp=1. Paths like /article/amp are changed
+// only for URLs of AMP caches, amp hosts or with an amp query parameter,
+// because they are valid paths of regular pages too.
+func ampToCanonical(u *url.URL) *url.URL {
+	canonical := *u
+	var amp bool
+	switch {
+	case strings.HasSuffix(u.Hostname(), ".cdn.ampproject.org"):
+		// https://example-com.cdn.ampproject.org/c/s/example.com/article
+		amp = ampCacheURL(&canonical, "/c/", "/v/")
+	case strings.TrimPrefix(u.Hostname(), "www.") == "google.com":
+		// https://www.google.com/amp/s/example.com/article
+		amp = ampCacheURL(&canonical, "/amp/")
+	}
+
+	// Keep hosts like amp.dev, which have nothing left but the top-level
+	// domain.
+	if host, ok := strings.CutPrefix(canonical.Hostname(), "amp."); ok &&
+		strings.Contains(host, ".") {
+		canonical.Host = strings.TrimPrefix(canonical.Host, "amp.")
+		amp = true
+	}
+
+	query := canonical.Query()
+	if query.Has("amp") || query.Get("outputType") == "amp" {
+		query.Del("amp")
+		query.Del("outputType")
+		canonical.RawQuery = query.Encode()
+		amp = true
+	}
+
+	switch {
+	case strings.HasSuffix(canonical.Path, ".amp.html"):
+		canonical.Path = strings.TrimSuffix(canonical.Path, ".amp.html") + ".html"
+	case !amp:
+		return &canonical
+	case strings.HasSuffix(canonical.Path, "/amp"),
+		strings.HasSuffix(canonical.Path, "/amp/"):
+		canonical.Path = strings.TrimSuffix(
+			strings.TrimSuffix(canonical.Path, "/"), "/amp")
+		if canonical.Path == "" {
+			canonical.Path = "/"
+		}
+	case strings.HasSuffix(canonical.Path, ".amp"):
+		canonical.Path = strings.TrimSuffix(canonical.Path, ".amp")
+	}
+	canonical.RawPath = ""
+	return &canonical
+}
+
+// ampCacheURL replaces the URL of an AMP cache, like
+// /c/s/example.com/article, where s means https, by the URL of the page. It
+// returns true if the URL has been replaced.
+func ampCacheURL(u *url.URL, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		rest, ok := strings.CutPrefix(u.Path, prefix)
+		if !ok {
+			continue
+		}
+
+		scheme := "http"
+		if s, ok := strings.CutPrefix(rest, "s/"); ok {
+			scheme, rest = "https", s
+		}
+
+		host, p, _ := strings.Cut(rest, "/")
+		if host == "" {
+			return false
+		}
+		u.Scheme, u.Host, u.Path, u.RawPath = scheme, host, "/"+p, ""
+		return true
+	}
+	return false
+}
+
+// followCanonical downloads the page and returns the URL of its canonical link
+// or the URL of the page after redirects.
+func followCanonical(ctx context.Context, rb *fetcher.RequestBuilder,
+	pageURL string,
+) (string, error) {
+	resp, err := rb.Request(ctx, pageURL)
+	if err != nil {
+		return "", fmt.Errorf("fetch page: %w", err)
+	}
+	defer resp.Close()
+
+	if lerr := resp.LocalizedError(); lerr != nil {
+		return "", lerr
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body())
+	if err != nil {
+		return "", fmt.Errorf("parse page: %w", err)
+	}
+	return canonicalURL(doc, resp.URL()), nil
+}
+
+// canonicalURL returns the absolute URL of the canonical link of the document
+// or baseURL without it.
+func canonicalURL(doc *goquery.Document, baseURL *url.URL) string {
+	href, ok := doc.Find(`link[rel="canonical"][href]`).First().Attr("href")
+	if !ok || strings.TrimSpace(href) == "" {
+		return baseURL.String()
+	}
+
+	u, err := baseURL.Parse(strings.TrimSpace(href))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return baseURL.String()
+	}
+	return u.String()
+}
@@ -4,8 +4,18 @@
 package rewrite // import "miniflux.app/v2/internal/reader/rewrite"
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/model"
 )
 
@@ -296,3 +306,193 @@ func TestCustomReplaceRuleRegex(t *testing.T) {
 		})
 	}
 }
+
+func TestRewriteEntryURL_chained(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    string
+		url      string
+		expected string
+	}{
+		{
+			name:     "strip all query parameters",
+			rules:    "strip_query",
+			url:      "https://example.com/article?utm_source=rss&id=1#top",
+			expected: "https://example.com/article#top",
+		},
+		{
+			name:     "strip query parameters by patterns",
+			rules:    `strip_query("utm_*"|"ref")`,
+			url:      "https://example.com/article?utm_source=rss&utm_medium=feed&ref=x&id=1",
+			expected: "https://example.com/article?id=1",
+		},
+		{
+			name:     "force https",
+			rules:    "force_https",
+			url:      "http://example.com/article",
+			expected: "https://example.com/article",
+		},
+		{
+			name:     "amp cache",
+			rules:    "amp_to_canonical",
+			url:      "https://example-com.cdn.ampproject.org/c/s/example.com/article?id=1",
+			expected: "https://example.com/article?id=1",
+		},
+		{
+			name:     "google amp",
+			rules:    "amp_to_canonical",
+			url:      "https://www.google.com/amp/s/example.com/article/amp/",
+			expected: "https://example.com/article",
+		},
+		{
+			name:     "amp path",
+			rules:    "amp_to_canonical",
+			url:      "https://amp.example.com/news/article/amp?amp=1&id=1",
+			expected: "https://example.com/news/article?id=1",
+		},
+		{
+			name:     "amp html",
+			rules:    "amp_to_canonical",
+			url:      "https://example.com/article.amp.html",
+			expected: "https://example.com/article.html",
+		},
+		{
+			name:     "amp path of regular page",
+			rules:    "amp_to_canonical",
+			url:      "https://example.com/tags/amp",
+			expected: "https://example.com/tags/amp",
+		},
+		{
+			name:     "amp host without subdomain",
+			rules:    "amp_to_canonical",
+			url:      "https://amp.dev/documentation/amp",
+			expected: "https://amp.dev/documentation/amp",
+		},
+		{
+			name:     "amp query",
+			rules:    "amp_to_canonical",
+			url:      "https://example.com/article/amp/?outputType=amp",
+			expected: "https://example.com/article",
+		},
+		{
+			name: "chained rules",
+			rules: "rewrite(`^http://m\\.example\\.com/(.+)`|\"http://example.com/$1\")\n" +
+				"force_https, strip_query(\"utm_*\")",
+			url:      "http://m.example.com/article?utm_source=rss&id=1",
+			expected: "https://example.com/article?id=1",
+		},
+		{
+			name:     "invalid rules",
+			rules:    "force_https, unknown_rule",
+			url:      "http://example.com/article",
+			expected: "http://example.com/article",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := &model.Feed{UrlRewriteRules: tt.rules}
+			entry := &model.Entry{URL: tt.url}
+			RewriteEntryURL(t.Context(), feed, entry)
+			assert.Equal(t, tt.expected, entry.URL)
+		})
+	}
+}
+
+func TestRewriteEntryURLWithoutFetch(t *testing.T) {
+	t.Setenv("FETCHER_ALLOW_PRIVATE_NETWORKS", "1")
+	require.NoError(t, config.Load(""))
+
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><head><link rel="canonical" href="/canonical"></head></html>`))
+		}))
+	defer ts.Close()
+
+	feed := &model.Feed{UrlRewriteRules: "strip_query, follow_canonical"}
+
+	entry := &model.Entry{URL: ts.URL + "/article?utm_source=rss"}
+	RewriteEntryURL(t.Context(), feed, entry, WithoutFetch(true))
+	assert.Equal(t, ts.URL+"/article", entry.URL)
+	assert.Zero(t, hits.Load())
+
+	entry = &model.Entry{URL: ts.URL + "/article?utm_source=rss"}
+	RewriteEntryURL(t.Context(), feed, entry, WithoutFetch(false))
+	assert.Equal(t, ts.URL+"/canonical", entry.URL)
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+func TestValidateURLRewriteRules(t *testing.T) {
+	tests := []struct {
+		rules   string
+		wantErr bool
+	}{
+		{rules: ""},
+		{rules: `rewrite("^https://news\.ycombinator\.com/item\?id=(.+)"|"https://hn.algolia.com/api/v1/items/$1")`},
+		{rules: "strip_query, force_https\namp_to_canonical,follow_canonical"},
+		{rules: "rewrite(`^https://example\\.com/(.+)`|\"https://example.org/$1\")"},
+		{rules: `rewrite("^https://example.com/[invalid"|"x")`, wantErr: true},
+		{rules: `rewrite("^https://example.com/"|"")`, wantErr: true},
+		{rules: `rewrite("^https://example.com/")`, wantErr: true},
+		{rules: `force_https("x")`, wantErr: true},
+		{rules: `strip_query("[")`, wantErr: true},
+		{rules: `unknown_rule`, wantErr: true},
+		{rules: `"x"`, wantErr: true},
+		{rules: `rewrite("\."|"x"), force_https`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			err := ValidateURLRewriteRules(tt.rules)
+			if tt.wantErr {
+				t.Log(err)
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	baseURL, err := url.Parse("https://example.com/article/amp")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "relative canonical",
+			html:     `<html><head><link rel="canonical" href="/article"></head></html>`,
+			expected: "https://example.com/article",
+		},
+		{
+			name:     "absolute canonical",
+			html:     `<link rel="canonical" href="https://example.org/article">`,
+			expected: "https://example.org/article",
+		},
+		{
+			name:     "without canonical",
+			html:     `<link rel="alternate" href="/feed.xml">`,
+			expected: "https://example.com/article/amp",
+		},
+		{
+			name:     "invalid scheme",
+			html:     `<link rel="canonical" href="javascript:alert(1)">`,
+			expected: "https://example.com/article/amp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, canonicalURL(doc, baseURL))
+		})
+	}
+}
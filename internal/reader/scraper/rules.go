@@ -3,10 +3,35 @@
 
 package scraper // import "miniflux.app/v2/internal/reader/scraper"
 
-import "strings"
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"golang.org/x/net/html"
+)
+
+const (
+	// defaultMaxPages is the default maximum number of pages of a multi-page
+	// article, including the first page.
+	defaultMaxPages = 5
+	// maxPagesLimit is the upper bound of the next_page cap.
+	maxPagesLimit = 20
+)
+
+// funcRulesRegex matches rules in the function format, like keep("article").
+// Everything else is a plain list of CSS selectors.
+var funcRulesRegex = regexp.MustCompile(`^\s*[a-z_]+\s*\(`)
 
 // List of predefined scraper rules (alphabetically sorted)
-// domain => CSS selectors
+// domain => CSS selectors or rules, see parseRules
 var predefinedRules = map[string]string{
 	"arstechnica.com":      "div.post-content",
 	"bbc.co.uk":            "div.vxp-column--single, div.story-body__inner, ul.gallery-images__list",
@@ -74,3 +99,240 @@ func domainRules(hostname string) string {
 		hostname = domain
 	}
 }
+
+// ValidateRules returns an error if scraper rules can't be parsed. See
+// parseRules for the format of rules.
+func ValidateRules(rules string) error {
+	_, err := parseRules(rules)
+	return err
+}
+
+// rules are parsed scraper rules.
+type rules struct {
+	source   string
+	keep     []selector
+	remove   []selector
+	nextPage selector
+	maxPages int
+}
+
+// selector selects nodes of a document by CSS selectors or XPath expressions.
+type selector interface {
+	find(s *goquery.Selection) *goquery.Selection
+}
+
+type cssSelector struct {
+	group cascadia.SelectorGroup
+}
+
+func (self cssSelector) find(s *goquery.Selection) *goquery.Selection {
+	var nodes []*html.Node
+	for _, n := range s.Nodes {
+		nodes = append(nodes, cascadia.QueryAll(n, self.group)...)
+	}
+	return s.FindNodes(nodes...)
+}
+
+type xpathSelector struct {
+	expr *xpath.Expr
+}
+
+// find returns element and text nodes, selected by the XPath expression.
+// Attribute nodes aren't part of the document and are skipped.
+func (self xpathSelector) find(s *goquery.Selection) *goquery.Selection {
+	var nodes []*html.Node
+	for _, n := range s.Nodes {
+		nodes = append(nodes, htmlquery.QuerySelectorAll(n, self.expr)...)
+	}
+	return s.FindNodes(nodes...)
+}
+
+// parseRules parses scraper rules. Rules are either a plain list of CSS
+// selectors of content to keep, like "article, .gallery", or a list of
+// functions, separated by commas or new lines:
+//
+//   - keep("css") and keep_xpath("xpath") select content to keep. Without
+//     them content is extracted by readability.
+//   - remove("css") and remove_xpath("xpath") select elements to strip, like
+//     ads or share widgets, before content is extracted.
+//   - next_page("css"|"5") and next_page_xpath("xpath"|"5") select the link to
+//     the next page of a multi-page article and optionally cap the number of
+//     pages.
+//
+// Arguments are Go strings, double quoted or raw in backquotes.
+func parseRules(s string) (*rules, error) {
+	r, err := readRules(s)
+	if err != nil {
+		return nil, fmt.Errorf("reader/scraper: invalid rules %q: %w", s, err)
+	}
+	return r, nil
+}
+
+func readRules(s string) (*rules, error) {
+	r := &rules{source: s, maxPages: defaultMaxPages}
+	if !funcRulesRegex.MatchString(s) {
+		group, err := cascadia.ParseGroup(s)
+		if err != nil {
+			return nil, err
+		}
+		r.keep = append(r.keep, cssSelector{group})
+		return r, nil
+	}
+
+	var err error
+	scan := scanner.Scanner{
+		Mode: scanner.ScanIdents | scanner.ScanStrings | scanner.ScanRawStrings,
+	}
+	scan.Init(strings.NewReader(s))
+	scan.Error = func(s *scanner.Scanner, msg string) {
+		if err == nil {
+			err = fmt.Errorf("%s: %s", s.Pos(), msg)
+		}
+	}
+
+	for tok := scan.Scan(); tok != scanner.EOF && err == nil; tok = scan.Scan() {
+		if tok == ',' {
+			continue
+		} else if tok != scanner.Ident {
+			return nil, fmt.Errorf("%s: unexpected %s, expected rule name",
+				scan.Position, scan.TokenText())
+		}
+
+		pos, name := scan.Position, scan.TokenText()
+		args, aerr := readArgs(&scan)
+		if aerr != nil {
+			return nil, aerr
+		} else if err := r.add(name, args); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", pos, name, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// readArgs reads arguments of a rule like ("arg1"|"arg2").
+func readArgs(scan *scanner.Scanner) ([]string, error) {
+	if tok := scan.Scan(); tok != '(' {
+		return nil, fmt.Errorf("%s: unexpected %s, expected (", scan.Position,
+			scan.TokenText())
+	}
+
+	var args []string
+	for {
+		switch tok := scan.Scan(); tok {
+		case scanner.String, scanner.RawString:
+			arg, err := strconv.Unquote(scan.TokenText())
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid argument %s: %w", scan.Position,
+					scan.TokenText(), err)
+			}
+			args = append(args, arg)
+		default:
+			return nil, fmt.Errorf("%s: unexpected %s, expected argument",
+				scan.Position, scan.TokenText())
+		}
+
+		switch tok := scan.Scan(); tok {
+		case '|':
+		case ')':
+			return args, nil
+		default:
+			return nil, fmt.Errorf("%s: unexpected %s, expected | or )",
+				scan.Position, scan.TokenText())
+		}
+	}
+}
+
+func (self *rules) add(name string, args []string) error {
+	switch name {
+	case "keep", "keep_xpath", "remove", "remove_xpath":
+		if len(args) != 1 {
+			return fmt.Errorf("expected 1 argument, got %d", len(args))
+		}
+	case "next_page", "next_page_xpath":
+		if len(args) > 2 {
+			return fmt.Errorf("expected 1 or 2 arguments, got %d", len(args))
+		} else if self.nextPage != nil {
+			return fmt.Errorf("next page already defined")
+		}
+	default:
+		return fmt.Errorf("unknown rule")
+	}
+
+	sel, err := newSelector(name, args[0])
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "keep", "keep_xpath":
+		self.keep = append(self.keep, sel)
+	case "remove", "remove_xpath":
+		self.remove = append(self.remove, sel)
+	case "next_page", "next_page_xpath":
+		self.nextPage = sel
+		if len(args) < 2 {
+			return nil
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 || n > maxPagesLimit {
+			return fmt.Errorf("invalid number of pages %q, expected 1..%d",
+				args[1], maxPagesLimit)
+		}
+		self.maxPages = n
+	}
+	return nil
+}
+
+func newSelector(name, arg string) (selector, error) {
+	if strings.HasSuffix(name, "_xpath") {
+		expr, err := xpath.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid XPath expression %q: %w", arg, err)
+		}
+		return xpathSelector{expr}, nil
+	}
+
+	group, err := cascadia.ParseGroup(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSS selector %q: %w", arg, err)
+	}
+	return cssSelector{group}, nil
+}
+
+// nextPageURL returns the absolute URL of the next page of the document or nil.
+// The link is an element with href attribute or a node with the URL as text,
+// like //a[@rel="next"]/@href.
+func (self *rules) nextPageURL(doc *goquery.Document, pageURL *url.URL,
+) *url.URL {
+	if self.nextPage == nil {
+		return nil
+	}
+
+	var link string
+	if xs, ok := self.nextPage.(xpathSelector); ok {
+		// Attribute nodes aren't part of the document, see xpathSelector.find.
+		if n := htmlquery.QuerySelector(doc.Get(0), xs.expr); n != nil {
+			link = htmlquery.SelectAttr(n, "href")
+			if link == "" {
+				link = htmlquery.InnerText(n)
+			}
+		}
+	} else {
+		link, _ = self.nextPage.find(doc.Selection).First().Attr("href")
+	}
+
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return nil
+	}
+
+	u, err := pageURL.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+	u.Fragment = ""
+	return u
+}
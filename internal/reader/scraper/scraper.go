@@ -21,64 +21,217 @@ import (
 	"miniflux.app/v2/internal/urllib"
 )
 
+const (
+	// MethodRules extracts content selected by scraper rules.
+	MethodRules = "rules"
+	// MethodReadability extracts content by readability.
+	MethodReadability = "readability"
+)
+
+// Result is the content of a scraped web page and how it was extracted.
+type Result struct {
+	// URL is the URL of the page after redirects.
+	URL string
+	// BaseURL is the URL to resolve relative links of Content.
+	BaseURL string
+	// Method is MethodRules or MethodReadability. Readability is used too with
+	// scraper rules without keep rules.
+	Method string
+	// Rules are the applied scraper rules, empty without rules.
+	Rules string
+	// RulesError is why scraper rules were ignored.
+	RulesError string
+	// Pages is the number of fetched pages, more than 1 for multi-page
+	// articles.
+	Pages int
+	// HTMLSize is the size of fetched HTML documents in bytes.
+	HTMLSize int64
+	Content  string
+}
+
 func ScrapeWebsite(ctx context.Context, rb *fetcher.RequestBuilder, pageURL,
 	rules string,
-) (string, string, error) {
-	resp, err := rb.Request(ctx, pageURL)
+) (*Result, error) {
+	result := &Result{Method: MethodReadability, Pages: 1}
+	resp, r, err := fetchDocument(ctx, rb, pageURL, &result.HTMLSize)
 	if err != nil {
-		return "", "", fmt.Errorf("reader/scraper: scrape website: %w", err)
+		return nil, err
 	}
 	defer resp.Close()
+	result.URL = resp.URL().String()
+
+	// The entry URL could redirect somewhere else.
+	hostname := resp.URL().Hostname()
+	if rules == "" {
+		rules = domainRules(hostname)
+	}
+
+	sameSite := hostname == urllib.Domain(pageURL)
+	if sameSite && rules != "" {
+		parsed, err := parseRules(rules)
+		if err == nil {
+			result.Rules = rules
+			err = extractCustom(ctx, rb, r, resp.URL(), parsed, result)
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+		logging.FromContext(ctx).Warn("Ignore invalid scraper rules",
+			slog.String("url", pageURL), slog.Any("error", err))
+		result.RulesError = err.Error()
+	}
+
+	result.BaseURL, result.Content, err = Readability(ctx, r, resp.URL())
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fetchDocument requests the HTML document at pageURL and returns the response
+// with a reader of its body, which adds the number of read bytes to size.
+func fetchDocument(ctx context.Context, rb *fetcher.RequestBuilder,
+	pageURL string, size *int64,
+) (*fetcher.ResponseHandler, io.Reader, error) {
+	resp, err := rb.Request(ctx, pageURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reader/scraper: scrape website: %w", err)
+	}
 
 	if lerr := resp.LocalizedError(); lerr != nil {
+		resp.Close()
 		logging.FromContext(ctx).Warn("Unable to scrape website",
 			slog.String("url", pageURL), slog.Any("error", lerr))
-		return "", "", lerr
+		return nil, nil, lerr
 	}
 
 	if !allowedContentType(resp.ContentType()) {
-		return "", "", fmt.Errorf(
+		resp.Close()
+		return nil, nil, fmt.Errorf(
 			"reader/scraper: this resource is not a HTML document: %q",
 			resp.ContentType())
 	}
 
-	r, err := encoding.NewCharsetReader(resp.Body(), resp.ContentType())
+	body := &countingReader{r: resp.Body(), n: size}
+	r, err := encoding.NewCharsetReader(body, resp.ContentType())
 	if err != nil {
-		return "", "", fmt.Errorf(
+		resp.Close()
+		return nil, nil, fmt.Errorf(
 			"reader/scraper: unable to read HTML document with charset reader: %w",
 			err)
 	}
+	return resp, r, nil
+}
 
-	// The entry URL could redirect somewhere else.
-	hostname := resp.URL().Hostname()
-	if rules == "" {
-		rules = domainRules(hostname)
-	}
+// countingReader adds the number of bytes read from r to n.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
 
-	sameSite := hostname == urllib.Domain(pageURL)
-	if sameSite && rules != "" {
-		return extractCustom(ctx, r, resp.URL(), rules)
-	}
-	return Readability(ctx, r, resp.URL())
+func (self *countingReader) Read(p []byte) (int, error) {
+	n, err := self.r.Read(p)
+	*self.n += int64(n)
+	return n, err
 }
 
-func extractCustom(ctx context.Context, r io.Reader, u *url.URL, rules string,
-) (string, string, error) {
+func extractCustom(ctx context.Context, rb *fetcher.RequestBuilder,
+	r io.Reader, u *url.URL, rules *rules, result *Result,
+) error {
 	pageURL := u.String()
 	log := logging.FromContext(ctx).With(slog.String("url", pageURL))
-	log.Debug("Extracting content with custom rules", slog.String("rules", rules))
+	log.Debug("Extracting content with custom rules",
+		slog.String("rules", rules.source))
+
+	if len(rules.keep) > 0 {
+		result.Method = MethodRules
+	}
 
-	contentURL, content, err := findContentUsingCustomRules(r, rules)
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
-		return "", "", fmt.Errorf(
-			"reader/scraper: extracting custom content: %w", err)
-	} else if contentURL == "" {
-		return pageURL, content, nil
+		return fmt.Errorf("reader/scraper: extracting custom content: %w", err)
+	}
+
+	// Find the next page before removal rules strip the navigation.
+	nextURL := rules.nextPageURL(doc, u)
+	contentURL, content, err := findContent(ctx, doc, u, rules)
+	if err != nil {
+		return fmt.Errorf("reader/scraper: extracting custom content: %w", err)
+	}
+	result.Content = content
+
+	if nextURL != nil {
+		scrapeNextPages(ctx, rb, u, nextURL, rules, result)
+	}
+
+	if contentURL == "" {
+		result.BaseURL = pageURL
+		return nil
 	}
 
 	log.Debug("Using base URL from HTML document",
 		slog.String("base_url", contentURL))
-	return contentURL, content, nil
+	result.BaseURL = contentURL
+	return nil
+}
+
+// scrapeNextPages follows next page links of a multi-page article on the same
+// host, up to the maximum number of pages, and appends their content to
+// result. Errors stop following and are only logged, because the content of the
+// first page is good enough.
+func scrapeNextPages(ctx context.Context, rb *fetcher.RequestBuilder,
+	firstURL, nextURL *url.URL, rules *rules, result *Result,
+) {
+	log := logging.FromContext(ctx).With(slog.String("url", firstURL.String()))
+	visited := map[string]struct{}{firstURL.String(): {}}
+
+	for page := 2; nextURL != nil && page <= rules.maxPages; page++ {
+		pageURL := nextURL.String()
+		if _, ok := visited[pageURL]; ok {
+			break
+		} else if nextURL.Hostname() != firstURL.Hostname() {
+			log.Debug("Skip next page on another host",
+				slog.String("next_url", pageURL))
+			break
+		}
+		visited[pageURL] = struct{}{}
+
+		log.Debug("Scraping next page", slog.Int("page", page),
+			slog.String("next_url", pageURL))
+		s, next, err := scrapeNextPage(ctx, rb, pageURL, rules,
+			&result.HTMLSize)
+		if err != nil {
+			log.Warn("Unable to scrape next page", slog.Int("page", page),
+				slog.String("next_url", pageURL), slog.Any("error", err))
+			break
+		}
+		result.Content += s
+		result.Pages++
+		nextURL = next
+	}
+}
+
+func scrapeNextPage(ctx context.Context, rb *fetcher.RequestBuilder,
+	pageURL string, rules *rules, size *int64,
+) (string, *url.URL, error) {
+	resp, r, err := fetchDocument(ctx, rb, pageURL, size)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Close()
+
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("reader/scraper: parse next page: %w", err)
+	}
+
+	nextURL := rules.nextPageURL(doc, resp.URL())
+	_, content, err := findContent(ctx, doc, resp.URL(), rules)
+	if err != nil {
+		return "", nil, err
+	}
+	return content, nextURL, nil
 }
 
 func Readability(ctx context.Context, r io.Reader, u *url.URL) (string, string,
@@ -104,26 +257,40 @@ func Readability(ctx context.Context, r io.Reader, u *url.URL) (string, string,
 	return pageURL, content.String(), nil
 }
 
-func findContentUsingCustomRules(page io.Reader, rules string) (baseURL, extractedContent string, err error) {
-	document, err := goquery.NewDocumentFromReader(page)
-	if err != nil {
-		return "", "", fmt.Errorf("reader/scraper: %w", err)
-	}
-
-	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
+// findContent strips elements selected by removal rules from the document and
+// returns its base URL and the content selected by keep rules or, without keep
+// rules, the readable content.
+func findContent(ctx context.Context, doc *goquery.Document, u *url.URL,
+	rules *rules,
+) (baseURL, content string, err error) {
+	if hrefValue, exists := doc.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
 		hrefValue = strings.TrimSpace(hrefValue)
 		if urllib.IsAbsoluteURL(hrefValue) {
 			baseURL = hrefValue
 		}
 	}
 
-	var buf strings.Builder
-	document.Find(rules).Each(func(i int, s *goquery.Selection) {
-		if content, err := goquery.OuterHtml(s); err == nil {
-			buf.WriteString(content)
+	for _, sel := range rules.remove {
+		sel.find(doc.Selection).Remove()
+	}
+
+	if len(rules.keep) == 0 {
+		s, err := doc.Html()
+		if err != nil {
+			return "", "", fmt.Errorf("reader/scraper: render document: %w", err)
 		}
-	})
+		_, content, err := Readability(ctx, strings.NewReader(s), u)
+		return baseURL, content, err
+	}
 
+	var buf strings.Builder
+	for _, sel := range rules.keep {
+		sel.find(doc.Selection).Each(func(i int, s *goquery.Selection) {
+			if content, err := goquery.OuterHtml(s); err == nil {
+				buf.WriteString(content)
+			}
+		})
+	}
 	return baseURL, buf.String(), nil
 }
 
@@ -5,11 +5,41 @@ package scraper // import "miniflux.app/v2/internal/reader/scraper"
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
 )
 
+func findContentUsingCustomRules(page io.Reader, rules string,
+) (string, string, error) {
+	parsed, err := parseRules(rules)
+	if err != nil {
+		return "", "", err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(page)
+	if err != nil {
+		return "", "", err
+	}
+
+	u, _ := url.Parse("https://example.org/article")
+	return findContent(context.Background(), doc, u, parsed)
+}
+
 func TestGetPredefinedRules(t *testing.T) {
 	if domainRules("www.phoronix.com") == "" {
 		t.Error("Unable to find rule for phoronix.com")
@@ -137,3 +167,245 @@ func TestParseMissingBaseURLWithCustomRules(t *testing.T) {
 		t.Errorf(`Unexpected base URL, got %q instead of ""`, baseURL)
 	}
 }
+
+func TestPredefinedRulesAreValid(t *testing.T) {
+	for domain, rules := range predefinedRules {
+		assert.NoError(t, ValidateRules(rules), domain)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		rules string
+		valid bool
+	}{
+		{rules: "article, .gallery", valid: true},
+		{rules: "div:not(.ad)", valid: true},
+		{rules: `keep("article")`, valid: true},
+		{rules: "keep(\"article\"), remove(\".ad, .share\")\nkeep_xpath(`//figure`)",
+			valid: true},
+		{rules: `remove_xpath("//aside")`, valid: true},
+		{rules: `next_page("a.next")`, valid: true},
+		{rules: `next_page_xpath("//a[@rel='next']/@href"|"10")`, valid: true},
+		{rules: "article >"},
+		{rules: `keep("article >")`},
+		{rules: `keep_xpath("//[")`},
+		{rules: `keep()`},
+		{rules: `keep("a"|"b")`},
+		{rules: `keep("article"`},
+		{rules: `keep(article)`},
+		{rules: `unknown("article")`},
+		{rules: `next_page("a.next"|"0")`},
+		{rules: `next_page("a.next"|"21")`},
+		{rules: `next_page("a.next"|"many")`},
+		{rules: `next_page("a.next"), next_page("a.more")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			err := ValidateRules(tt.rules)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRemovalAndXPathRules(t *testing.T) {
+	html := `<html><body><article><p>Text</p><div class="ad">Ad</div>
+<aside>Related</aside><figure><img src="a.jpg"></figure></article>
+<footer>Footer</footer></body></html>`
+
+	tests := []struct {
+		rules string
+		want  string
+	}{
+		{
+			rules: `keep("p"), keep_xpath("//figure")`,
+			want:  `<p>Text</p><figure><img src="a.jpg"/></figure>`,
+		},
+		{
+			rules: `remove(".ad"), remove_xpath("//aside | //article/text()")
+keep_xpath("//article")`,
+			want: `<article><p>Text</p><figure><img src="a.jpg"/></figure></article>`,
+		},
+		{
+			rules: `keep_xpath("//p/text()")`,
+			want:  `Text`,
+		},
+		{
+			rules: `keep_xpath("//img/@src")`,
+			want:  ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			_, content, err := findContentUsingCustomRules(
+				strings.NewReader(html), tt.rules)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, content)
+		})
+	}
+}
+
+func TestRemovalRulesWithReadability(t *testing.T) {
+	html := `<html><body><article>
+<p>` + strings.Repeat("Lorem ipsum dolor sit amet, consectetuer adipiscing. ", 20) + `</p>
+<p class="promo">Subscribe to our newsletter</p>
+</article></body></html>`
+
+	_, content, err := findContentUsingCustomRules(strings.NewReader(html),
+		`remove(".promo")`)
+	require.NoError(t, err)
+	assert.Contains(t, content, "Lorem ipsum")
+	assert.NotContains(t, content, "newsletter")
+}
+
+func TestScrapeMultiPageArticle(t *testing.T) {
+	require.NoError(t, config.Load(""))
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path)
+			next := map[string]string{
+				"/1": "/2",
+				"/2": "/3#comments",
+				"/3": "/1",
+			}[r.URL.Path]
+			if next == "" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprintf(w, `<html><body><article><p>Page %s</p>
+<div class="share">Share</div></article>
+<a class="next" rel="next" href="%s">Next</a></body></html>`,
+				r.URL.Path, next)
+		}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		rules    string
+		want     string
+		requests []string
+	}{
+		{
+			name:     "default cap",
+			rules:    `keep("article"), remove(".share"), next_page("a.next")`,
+			requests: []string{"/1", "/2", "/3"},
+			want: "<article><p>Page /1</p>\n</article>" +
+				"<article><p>Page /2</p>\n</article>" +
+				"<article><p>Page /3</p>\n</article>",
+		},
+		{
+			name: "xpath with cap",
+			rules: `keep_xpath("//p")
+next_page_xpath("//a[@rel='next']/@href"|"2")`,
+			requests: []string{"/1", "/2"},
+			want:     "<p>Page /1</p><p>Page /2</p>",
+		},
+		{
+			name:     "no next page",
+			rules:    `keep("p")`,
+			requests: []string{"/1"},
+			want:     "<p>Page /1</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			result, err := ScrapeWebsite(t.Context(),
+				fetcher.NewRequestBuilder().WithPrivateNetworks(), server.URL+"/1",
+				tt.rules)
+			require.NoError(t, err)
+			assert.Equal(t, server.URL+"/1", result.BaseURL)
+			assert.Equal(t, tt.want, result.Content)
+			assert.Equal(t, MethodRules, result.Method)
+			assert.Equal(t, tt.rules, result.Rules)
+			assert.Equal(t, len(tt.requests), result.Pages)
+			assert.Positive(t, result.HTMLSize)
+			assert.Equal(t, tt.requests, requests)
+		})
+	}
+}
+
+func TestScrapeWebsiteWithReadability(t *testing.T) {
+	require.NoError(t, config.Load(""))
+
+	page := `<html><head><title>Title</title></head><body><article><p>` +
+		strings.Repeat("Lorem ipsum dolor sit amet, consectetuer adipiscing. ", 20) +
+		`</p></article></body></html>`
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, page)
+		}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		rules      string
+		rulesError bool
+	}{
+		{name: "without rules"},
+		{name: "removal rules", rules: `remove("title")`},
+		{name: "invalid rules", rules: "article >", rulesError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ScrapeWebsite(t.Context(),
+				fetcher.NewRequestBuilder().WithPrivateNetworks(), server.URL,
+				tt.rules)
+			require.NoError(t, err)
+			assert.Equal(t, MethodReadability, result.Method)
+			assert.Equal(t, 1, result.Pages)
+			assert.Equal(t, int64(len(page)), result.HTMLSize)
+			assert.Contains(t, result.Content, "Lorem ipsum")
+			if tt.rulesError {
+				assert.Empty(t, result.Rules)
+				assert.NotEmpty(t, result.RulesError)
+			} else {
+				assert.Equal(t, tt.rules, result.Rules)
+				assert.Empty(t, result.RulesError)
+			}
+		})
+	}
+}
+
+func TestScrapeWebsiteWithRenderService(t *testing.T) {
+	const pageURL = "https://example.org/article"
+	var rendered []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				URL string `json:"url"`
+			}
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) {
+				return
+			}
+			rendered = append(rendered, body.URL)
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, `<html><body><article><p>Rendered by JavaScript</p></article></body></html>`)
+		}))
+	defer server.Close()
+
+	os.Clearenv()
+	t.Setenv("RENDER_SERVICE_URL", server.URL+"/content")
+	require.NoError(t, config.Load(""))
+
+	result, err := ScrapeWebsite(t.Context(),
+		fetcher.NewRequestBuilder().WithPrivateNetworks().WithRenderService(),
+		pageURL, "article")
+	require.NoError(t, err)
+	assert.Equal(t, []string{pageURL}, rendered)
+	assert.Equal(t, pageURL, result.URL)
+	assert.Equal(t, MethodRules, result.Method)
+	assert.Contains(t, result.Content, "Rendered by JavaScript")
+}
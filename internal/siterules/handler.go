@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package siterules // import "miniflux.app/v2/internal/siterules"
+
+import (
+	"context"
+	"io"
+
+	"miniflux.app/v2/internal/storage"
+)
+
+// Handler handles the logic for site rules export and import.
+type Handler struct {
+	store *storage.Storage
+}
+
+// NewHandler creates a new handler for site rules documents.
+func NewHandler(store *storage.Storage) *Handler {
+	return &Handler{store: store}
+}
+
+// Export writes site rules of the user, or global site rules, when userID is
+// zero, as a YAML document.
+func (h *Handler) Export(ctx context.Context, userID int64, w io.Writer,
+) error {
+	rules, err := h.store.SiteRulesByOwner(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return Encode(w, rules)
+}
+
+// Import creates or replaces site rules of the user, or global site rules,
+// when userID is zero, from a YAML document. It returns the number of
+// imported site rules.
+func (h *Handler) Import(ctx context.Context, userID int64, r io.Reader,
+) (int, error) {
+	rules, err := Decode(r)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := h.store.ImportSiteRules(ctx, userID, rules); err != nil {
+		return 0, err
+	}
+	return len(rules), nil
+}
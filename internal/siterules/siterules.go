@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package siterules exports and imports site rules as YAML documents.
+package siterules // import "miniflux.app/v2/internal/siterules"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"go.yaml.in/yaml/v4"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+// ErrInvalidDocument is returned for YAML documents, which can't be imported.
+var ErrInvalidDocument = errors.New("siterules: invalid site rules document")
+
+// Encode writes site rules as a YAML list of mappings with keys hostname,
+// scraper_rules, rewrite_rules, referer and user_agent. Empty rules are
+// omitted.
+func Encode(w io.Writer, rules model.SiteRules) error {
+	requests := make([]model.SiteRuleRequest, len(rules))
+	for i := range rules {
+		requests[i] = rules[i].Request()
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(requests); err != nil {
+		return fmt.Errorf("siterules: encode site rules: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("siterules: close YAML encoder: %w", err)
+	}
+	return nil
+}
+
+// Decode reads and validates a YAML list of site rules, created by Encode.
+// Later rules replace previous rules with the same hostname.
+func Decode(r io.Reader) ([]model.SiteRuleRequest, error) {
+	var requests []model.SiteRuleRequest
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&requests); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+
+	rules := make([]model.SiteRuleRequest, 0, len(requests))
+	found := make(map[string]int, len(requests))
+	for i := range requests {
+		r := &requests[i]
+		if lerr := validator.ValidateSiteRuleRequest(r); lerr != nil {
+			return nil, fmt.Errorf("%w: site rule #%d: %w", ErrInvalidDocument,
+				i+1, lerr.Error())
+		}
+
+		if j, ok := found[r.Hostname]; ok {
+			rules[j] = *r
+			continue
+		}
+		found[r.Hostname] = len(rules)
+		rules = append(rules, *r)
+	}
+	return rules, nil
+}
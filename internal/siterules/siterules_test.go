@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package siterules // import "miniflux.app/v2/internal/siterules"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	rules := model.SiteRules{
+		{
+			ID:           1,
+			Hostname:     "example.com",
+			ScraperRules: "article",
+			RewriteRules: `add_image_title,remove(".ad")`,
+		},
+		{ID: 2, UserID: 1, Hostname: "example.org", UserAgent: "Mozilla/5.0"},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, Encode(&b, rules))
+	assert.NotContains(t, b.String(), "referer")
+
+	decoded, err := Decode(&b)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SiteRuleRequest{
+		{
+			Hostname:     "example.com",
+			ScraperRules: "article",
+			RewriteRules: `add_image_title,remove(".ad")`,
+		},
+		{Hostname: "example.org", UserAgent: "Mozilla/5.0"},
+	}, decoded)
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []model.SiteRuleRequest
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  []model.SiteRuleRequest{},
+		},
+		{
+			name: "normalized and deduplicated",
+			input: `
+- hostname: " Example.COM "
+  scraper_rules: article
+- hostname: example.com
+  scraper_rules: main`,
+			want: []model.SiteRuleRequest{
+				{Hostname: "example.com", ScraperRules: "main"},
+			},
+		},
+		{
+			name:    "unknown field",
+			input:   "- hostname: example.com\n  selector: article",
+			wantErr: true,
+		},
+		{
+			name:    "invalid hostname",
+			input:   "- hostname: https://example.com/\n  scraper_rules: article",
+			wantErr: true,
+		},
+		{
+			name:    "invalid scraper rules",
+			input:   "- hostname: example.com\n  scraper_rules: 'div['",
+			wantErr: true,
+		},
+		{
+			name:    "without rules",
+			input:   "- hostname: example.com",
+			wantErr: true,
+		},
+		{
+			name:    "not a list",
+			input:   "hostname: example.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := Decode(strings.NewReader(tt.input))
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidDocument)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rules)
+		})
+	}
+}
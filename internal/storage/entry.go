@@ -105,6 +105,26 @@ func (s *Storage) GetReadTime(ctx context.Context, feedID int64,
 	return readingTime
 }
 
+// WeeklyFeedEntryCount returns the number of entries published during the
+// last week.
+func (s *Storage) WeeklyFeedEntryCount(ctx context.Context, userID,
+	feedID int64,
+) (int, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT count(*)
+  FROM entries
+ WHERE user_id = $1 AND feed_id = $2
+       AND published_at >= now() - interval '1 week'`,
+		userID, feedID)
+
+	n, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("storage: count weekly entries of feed #%d: %w",
+			feedID, err)
+	}
+	return n, nil
+}
+
 // StoreFeedEntries updates feed entries while refreshing a feed.
 func (s *Storage) StoreFeedEntries(ctx context.Context, userID, feedID int64,
 	entries model.Entries, forceUpdate bool,
@@ -220,7 +240,7 @@ func (s *Storage) updateEntries(ctx context.Context, tx pgx.Tx,
 }
 
 func (s *Storage) queueUpdateEntry(batch *pgx.Batch, e *model.Entry) {
-	args := append(make([]any, 0, 14),
+	args := append(make([]any, 0, 15),
 		e.UserID, e.FeedID, e.Hash,
 		e.Title,
 		e.URL,
@@ -231,7 +251,8 @@ func (s *Storage) queueUpdateEntry(batch *pgx.Batch, e *model.Entry) {
 		e.Tags,
 		e.Date,
 		e.Status,
-		&e.Extra)
+		&e.Extra,
+		e.Language())
 
 	withStarred := func() string {
 		if !e.Imported() {
@@ -253,7 +274,8 @@ UPDATE entries
        changed_at = now(),
        published_at = $11,
        status = $12,
-       extra = $13` + withStarred() + `
+       extra = $13,
+       language = $14` + withStarred() + `
  WHERE user_id = $1 AND feed_id = $2 AND hash = $3
 RETURNING id, changed_at`
 
@@ -298,6 +320,7 @@ func (s *Storage) createEntries(ctx context.Context, tx pgx.Tx,
 			"tags",
 			"changed_at",
 			"extra",
+			"language",
 		},
 		pgx.CopyFromSlice(len(entries), func(i int) ([]any, error) {
 			e := entries[i]
@@ -319,6 +342,7 @@ func (s *Storage) createEntries(ctx context.Context, tx pgx.Tx,
 				e.Tags,
 				now,
 				&e.Extra,
+				e.Language(),
 			}, nil
 		}))
 	if err != nil {
@@ -382,8 +406,10 @@ INSERT INTO entries (
   tags,
   extra,
   starred,
+  language,
   changed_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, now())
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
+        now())
 RETURNING id, created_at, changed_at`,
 		e.Status,
 		e.Title,
@@ -399,6 +425,7 @@ RETURNING id, created_at, changed_at`,
 		e.Tags,
 		&e.Extra,
 		e.Starred,
+		e.Language(),
 	).QueryRow(func(row pgx.Row) error {
 		err := row.Scan(&e.ID, &e.CreatedAt, &e.ChangedAt)
 		if err != nil {
@@ -433,8 +460,36 @@ DELETE FROM entries
 	return int(result.RowsAffected()), nil
 }
 
+// archiveEntriesSet is the SET clause of archived entries, which clears the
+// content fields, keeping only their metadata.
+const archiveEntriesSet = `
+   SET status       = 'removed',` + compactEntriesSet
+
+// compactEntriesSet is the part of archiveEntriesSet which clears the content
+// fields.
+const compactEntriesSet = `
+       title        = '',
+       url          = '',
+       author       = NULL,
+       content      = NULL,
+       comments_url = NULL,
+       tags         = NULL,
+       extra        = '{}'`
+
+// retentionFeeds selects effective retention policies of feeds, which have
+// one, or their category has one.
+const retentionFeeds = `
+SELECT f.id AS feed_id,
+       CASE WHEN f.extra ? 'retention' THEN f.extra->'retention'
+            ELSE c.extra->'retention' END AS retention
+  FROM feeds f
+  JOIN categories c ON c.id = f.category_id
+ WHERE f.extra ? 'retention' OR c.extra ? 'retention'`
+
 // ArchiveEntries changes the status of entries to "removed" after the given
 // number of days and clears the content fields, keeping only their metadata.
+// Entries of feeds with a retention policy are skipped, see
+// ArchiveEntriesByRetention.
 func (s *Storage) ArchiveEntries(ctx context.Context, status string,
 	days, limit int,
 ) (int64, error) {
@@ -443,27 +498,19 @@ func (s *Storage) ArchiveEntries(ctx context.Context, status string,
 	}
 
 	result, err := s.db.Exec(ctx, `
-UPDATE entries
-   SET status       = $1,
-       title        = '',
-       url          = '',
-       author       = NULL,
-       content      = NULL,
-       comments_url = NULL,
-       tags         = NULL,
-       extra        = '{}'
+UPDATE entries`+archiveEntriesSet+`
  WHERE id IN (
    SELECT id
      FROM entries
-    WHERE status = $2
-          AND changed_at < now () - $3::interval
+    WHERE status = $1
+          AND changed_at < now () - $2::interval
           AND starred IS FALSE
+          AND feed_id NOT IN (SELECT feed_id FROM (`+retentionFeeds+`) r)
     ORDER BY changed_at ASC
     FOR UPDATE SKIP LOCKED
-    LIMIT $4
+    LIMIT $3
  )`,
-		model.EntryStatusRemoved, status,
-		strconv.FormatInt(int64(days), 10)+" days", limit)
+		status, strconv.FormatInt(int64(days), 10)+" days", limit)
 	if err != nil {
 		return 0, fmt.Errorf(`store: unable to archive %s entries: %w`,
 			status, err)
@@ -471,11 +518,81 @@ UPDATE entries
 	return result.RowsAffected(), nil
 }
 
+// ArchiveEntriesByRetention archives entries of feeds with a retention policy
+// of the feed or of its category, see model.Retention.
+func (s *Storage) ArchiveEntriesByRetention(ctx context.Context, limit int,
+) (int64, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	result, err := s.db.Exec(ctx, `
+WITH r AS (
+  SELECT feed_id,
+         coalesce((retention->>'never')::bool, false) AS never,
+         coalesce((retention->>'days')::int, 0) AS days,
+         coalesce((retention->>'keep')::int, 0) AS keep
+    FROM (`+retentionFeeds+`) t
+),
+e AS (
+  SELECT e.id, e.starred, e.changed_at, r.days, r.keep,
+         row_number() OVER (PARTITION BY e.feed_id
+                            ORDER BY e.published_at DESC, e.id DESC) AS n
+    FROM entries e
+    JOIN r ON r.feed_id = e.feed_id
+   WHERE e.status <> 'removed' AND NOT r.never
+)
+UPDATE entries`+archiveEntriesSet+`
+ WHERE id IN (
+   SELECT id
+     FROM e
+    WHERE starred IS FALSE
+          AND ((days > 0 AND changed_at < now() - make_interval(days => days))
+               OR (keep > 0 AND n > keep))
+    ORDER BY changed_at ASC
+    LIMIT $1
+ )`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("store: unable to archive entries by retention: %w",
+			err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// CompactRemovedEntries clears the content fields of removed entries, which
+// weren't changed for the given number of days, like entries removed by
+// FlushHistory. The hash is kept to prevent the same entry from being
+// imported again. It returns the number of compacted entries.
+func (s *Storage) CompactRemovedEntries(ctx context.Context, days, limit int,
+) (int64, error) {
+	if days < 0 || limit <= 0 {
+		return 0, nil
+	}
+
+	result, err := s.db.Exec(ctx, `
+UPDATE entries
+   SET`+compactEntriesSet+`
+ WHERE id IN (
+   SELECT id
+     FROM entries
+    WHERE status = 'removed'
+          AND changed_at < now() - make_interval(days => $1)
+          AND (content IS NOT NULL OR title <> '' OR url <> '')
+    ORDER BY changed_at ASC
+    FOR UPDATE SKIP LOCKED
+    LIMIT $2
+ )`, days, limit)
+	if err != nil {
+		return 0, fmt.Errorf("store: unable to compact removed entries: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
 // SetEntriesStatus update the status of the given list of entries.
 func (s *Storage) SetEntriesStatus(ctx context.Context, userID int64,
 	entryIDs []int64, status string,
 ) error {
-	_, err := s.db.Exec(ctx, `
+	result, err := s.db.Exec(ctx, `
 UPDATE entries
    SET status = $1, changed_at = now()
  WHERE user_id = $2 AND id = ANY($3) AND status <> $4`,
@@ -483,10 +600,30 @@ UPDATE entries
 	if err != nil {
 		return fmt.Errorf(`store: unable to update entries statuses %v: %w`,
 			entryIDs, err)
+	} else if result.RowsAffected() != 0 {
+		s.events.Publish(userID, model.NewStatusEvent(status, entryIDs...))
 	}
 	return nil
 }
 
+// UpdateEntriesStatus changes the status of the given list of entries and
+// returns the number of changed entries.
+func (s *Storage) UpdateEntriesStatus(ctx context.Context, userID int64,
+	entryIDs []int64, status string,
+) (int, error) {
+	result, err := s.db.Exec(ctx, `
+UPDATE entries
+   SET status = $1, changed_at = now()
+ WHERE user_id = $2 AND id = ANY($3) AND status NOT IN ($1, $4)`,
+		status, userID, entryIDs, model.EntryStatusRemoved)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to update entries statuses: %w`, err)
+	} else if result.RowsAffected() != 0 {
+		s.events.Publish(userID, model.NewStatusEvent(status, entryIDs...))
+	}
+	return int(result.RowsAffected()), nil
+}
+
 func (s *Storage) SetEntriesStatusCount(ctx context.Context, userID int64,
 	entryIDs []int64, status string,
 ) (int, error) {
@@ -497,20 +634,24 @@ WITH updated AS (
    WHERE user_id = $2 AND id = ANY($3) AND status <> $4
    RETURNING feed_id
 )
-SELECT count(*)
+SELECT count(*) AS updated,
+       count(*) FILTER (WHERE NOT f.hide_globally AND NOT c.hide_globally)
+         AS visible
   FROM updated u
 		   JOIN feeds f ON f.id = u.feed_id
-		   JOIN categories c ON c.id = f.category_id
- WHERE NOT f.hide_globally AND NOT c.hide_globally`,
+		   JOIN categories c ON c.id = f.category_id`,
 		status, userID, entryIDs, model.EntryStatusRemoved)
 
-	visible, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[int])
+	counts, err := pgx.CollectExactlyOneRow(rows,
+		pgx.RowToStructByName[struct{ Updated, Visible int }])
 	if err != nil {
 		return 0, fmt.Errorf(
 			"storage: unable update entries statuses and count visible %v: %w",
 			entryIDs, err)
+	} else if counts.Updated != 0 {
+		s.events.Publish(userID, model.NewStatusEvent(status, entryIDs...))
 	}
-	return visible, nil
+	return counts.Visible, nil
 }
 
 // SetEntriesBookmarkedState updates the bookmarked state for the given list of
@@ -531,37 +672,52 @@ UPDATE entries
 	if result.RowsAffected() == 0 {
 		return errors.New(`store: nothing has been updated`)
 	}
+
+	s.events.Publish(userID, &model.Event{
+		Type:     model.EventEntriesStarred,
+		EntryIDs: entryIDs,
+		Starred:  &starred,
+	})
 	return nil
 }
 
 // ToggleBookmark toggles entry bookmark value.
 func (s *Storage) ToggleBookmark(ctx context.Context, userID, entryID int64,
 ) error {
-	result, err := s.db.Exec(ctx, `
+	rows, _ := s.db.Query(ctx, `
 UPDATE entries
    SET starred = NOT starred, changed_at=now()
- WHERE user_id=$1 AND id=$2`,
+ WHERE user_id=$1 AND id=$2
+RETURNING starred`,
 		userID, entryID)
-	if err != nil {
+
+	starred, err := pgx.CollectOneRow(rows, pgx.RowTo[bool])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New(`store: nothing has been updated`)
+	} else if err != nil {
 		return fmt.Errorf(
 			`store: unable to toggle bookmark flag for entry #%d: %w`, entryID, err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return errors.New(`store: nothing has been updated`)
-	}
+	s.events.Publish(userID, &model.Event{
+		Type:     model.EventEntriesStarred,
+		EntryIDs: []int64{entryID},
+		Starred:  &starred,
+	})
 	return nil
 }
 
 // FlushHistory changes all entries with the status "read" to "removed".
 func (s *Storage) FlushHistory(ctx context.Context, userID int64) error {
-	_, err := s.db.Exec(ctx, `
+	result, err := s.db.Exec(ctx, `
 UPDATE entries
    SET status = $1, changed_at = now()
  WHERE user_id = $2 AND status = $3 AND starred IS FALSE`,
 		model.EntryStatusRemoved, userID, model.EntryStatusRead)
 	if err != nil {
 		return fmt.Errorf(`store: unable to flush history: %w`, err)
+	} else if result.RowsAffected() != 0 {
+		s.events.Publish(userID, model.NewStatusEvent(model.EntryStatusRemoved))
 	}
 	return nil
 }
@@ -580,6 +736,7 @@ UPDATE entries
 	logging.FromContext(ctx).Debug("Marked all entries as read",
 		slog.Int64("user_id", userID),
 		slog.Int64("nb_entries", result.RowsAffected()))
+	s.publishMarkedAsRead(userID, 0, result.RowsAffected())
 	return nil
 }
 
@@ -603,6 +760,7 @@ UPDATE entries
 		slog.Int64("user_id", userID),
 		slog.Int64("nb_entries", result.RowsAffected()),
 		slog.String("before", before.Format(time.RFC3339)))
+	s.publishMarkedAsRead(userID, 0, result.RowsAffected())
 	return nil
 }
 
@@ -633,6 +791,7 @@ UPDATE entries
 		"Marked globally visible feed entries as read",
 		slog.Int64("user_id", userID),
 		slog.Int64("nb_entries", result.RowsAffected()))
+	s.publishMarkedAsRead(userID, 0, result.RowsAffected())
 	return nil
 }
 
@@ -655,6 +814,7 @@ UPDATE entries
 		slog.Int64("feed_id", feedID),
 		slog.Int64("nb_entries", result.RowsAffected()),
 		slog.String("before", before.Format(time.RFC3339)))
+	s.publishMarkedAsRead(userID, feedID, result.RowsAffected())
 	return result.RowsAffected() != 0, nil
 }
 
@@ -679,9 +839,19 @@ UPDATE entries
 		slog.Int64("category_id", categoryID),
 		slog.Int64("nb_entries", result.RowsAffected()),
 		slog.String("before", before.Format(time.RFC3339)))
+	s.publishMarkedAsRead(userID, 0, result.RowsAffected())
 	return result.RowsAffected() != 0, nil
 }
 
+// publishMarkedAsRead publishes the event of entries of the feed or of all
+// feeds, if feedID is zero, marked as read.
+func (s *Storage) publishMarkedAsRead(userID, feedID, affected int64) {
+	if affected != 0 {
+		s.events.Publish(userID,
+			model.NewStatusEvent(model.EntryStatusRead).WithFeedID(feedID))
+	}
+}
+
 func (s *Storage) KnownEntryHashes(ctx context.Context, feedID int64,
 	hashes []string,
 ) ([]model.Entry, error) {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"iter"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +44,9 @@ type EntryQueryBuilder struct {
 
 	numberedRows bool
 	highConds    []string
+
+	// searchArg is the position of the full-text search query argument.
+	searchArg string
 }
 
 func (self *EntryQueryBuilder) appendCondition(prefix string, arg any,
@@ -76,20 +80,78 @@ func (self *EntryQueryBuilder) WithAuthor(name string) *EntryQueryBuilder {
 }
 
 // WithSearchQuery adds full-text search query to the condition.
+//
+// Search vectors of entries are built with the text search configuration of
+// the entry language, see entry_search_config(). entry_search_query() combines
+// the query parsed with every known configuration, so every entry is matched
+// with its own configuration, while the index on document_vectors still can be
+// used.
 func (self *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
 	if query == "" {
 		return self
 	}
 
 	argPos := self.appendCondition(
-		"e.document_vectors @@ websearch_to_tsquery($", query, ")")
+		"e.document_vectors @@ entry_search_query($", query, ")")
+	self.searchArg = argPos
 
 	// 0.0000001 = 0.1 / (seconds_in_a_day)
 	self.sortExpressions = append(self.sortExpressions,
-		`ts_rank(e.document_vectors, websearch_to_tsquery($`+argPos+`)) - extract(epoch from now() - e.published_at)::float * 0.0000001 DESC`)
+		`ts_rank(e.document_vectors, entry_search_query($`+argPos+`)) - extract(epoch from now() - e.published_at)::float * 0.0000001 DESC`)
 	return self
 }
 
+// WithSearch adds conditions of a parsed search query.
+func (self *EntryQueryBuilder) WithSearch(q *model.SearchQuery,
+) *EntryQueryBuilder {
+	for _, title := range q.Titles {
+		self.appendCondition("e.title ILIKE $", containsPattern(title), "")
+	}
+
+	for _, author := range q.Authors {
+		self.appendCondition("e.author ILIKE $", containsPattern(author), "")
+	}
+
+	for _, feed := range q.Feeds {
+		self.appendCondition("f.title ILIKE $", containsPattern(feed), "")
+	}
+
+	for _, category := range q.Categories {
+		self.appendCondition("c.title ILIKE $", containsPattern(category), "")
+	}
+
+	for _, u := range q.URLs {
+		self.appendCondition("e.url ILIKE $", containsPattern(u), "")
+	}
+
+	for _, domain := range q.Domains {
+		self.appendCondition("e.url ~* $", `^[a-z][a-z0-9+.-]*://([^/?#@]*@)?`+
+			`([^/?#]*\.)?`+regexp.QuoteMeta(domain)+`(:[0-9]+)?([/?#]|$)`, "")
+	}
+
+	if q.Starred != nil {
+		self.WithStarred(*q.Starred)
+	}
+
+	if !q.After.IsZero() {
+		self.appendCondition("e.published_at >= $", q.After, "")
+	}
+
+	if !q.Before.IsZero() {
+		self.appendCondition("e.published_at < $", q.Before, "")
+	}
+
+	return self.WithTags(q.Tags).
+		WithStatus(q.Status).
+		WithSearchQuery(q.Text)
+}
+
+// containsPattern returns a LIKE pattern matching strings containing s.
+func containsPattern(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return "%" + r.Replace(s) + "%"
+}
+
 // WithStarred adds starred filter.
 func (self *EntryQueryBuilder) WithStarred(starred bool) *EntryQueryBuilder {
 	if starred {
@@ -100,6 +162,23 @@ func (self *EntryQueryBuilder) WithStarred(starred bool) *EntryQueryBuilder {
 	return self
 }
 
+// WithLabelID filter by a label of entries.
+func (self *EntryQueryBuilder) WithLabelID(labelID int64) *EntryQueryBuilder {
+	if labelID > 0 {
+		self.appendCondition(
+			"e.id IN (SELECT entry_id FROM entry_labels WHERE label_id = $",
+			labelID, ")")
+	}
+	return self
+}
+
+// WithStarredOrLabeled filter entries, which are starred or have any label.
+func (self *EntryQueryBuilder) WithStarredOrLabeled() *EntryQueryBuilder {
+	self.appendCondition(`(e.starred is true OR EXISTS (
+  SELECT FROM entry_labels el WHERE el.entry_id = e.id))`, nil, "")
+	return self
+}
+
 // BeforeChangedDate adds a condition < changed_at
 func (self *EntryQueryBuilder) BeforeChangedDate(date time.Time,
 ) *EntryQueryBuilder {
@@ -275,19 +354,50 @@ func (self *EntryQueryBuilder) WithGloballyVisible() *EntryQueryBuilder {
 
 // CountEntries count the number of entries that match the condition.
 func (self *EntryQueryBuilder) CountEntries(ctx context.Context) (int, error) {
-	query := `
+	rows, _ := self.db.Query(ctx, self.countQuery(), self.args...)
+	count, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("store: unable to count entries: %w", err)
+	}
+	return count, nil
+}
+
+// queueCount queues the count of entries into the batch, which scans it into
+// count.
+func (self *EntryQueryBuilder) queueCount(batch *pgx.Batch, count *int) {
+	batch.Queue(self.countQuery(), self.args...).
+		QueryRow(func(row pgx.Row) error { return row.Scan(count) })
+}
+
+func (self *EntryQueryBuilder) countQuery() string {
+	return `
 SELECT count(*)
   FROM entries e
 	     JOIN feeds f ON f.id = e.feed_id
 	     JOIN categories c ON c.id = f.category_id
  WHERE ` + self.buildCondition()
+}
 
-	rows, _ := self.db.Query(ctx, query, self.args...)
-	count, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[int])
+// MarkAsRead updates unread entries that match the condition to the status
+// read. It returns the number of updated entries.
+func (self *EntryQueryBuilder) MarkAsRead(ctx context.Context) (int64, error) {
+	self.WithStatus(model.EntryStatusUnread)
+	self.args = append(self.args, model.EntryStatusRead)
+	query := `
+UPDATE entries
+   SET status = $` + strconv.Itoa(len(self.args)) + `, changed_at = now()
+ WHERE id IN (
+   SELECT e.id
+     FROM entries e
+          JOIN feeds f ON f.id = e.feed_id
+          JOIN categories c ON c.id = f.category_id
+    WHERE ` + self.buildCondition() + `)`
+
+	result, err := self.db.Exec(ctx, query, self.args...)
 	if err != nil {
-		return 0, fmt.Errorf("store: unable to count entries: %w", err)
+		return 0, fmt.Errorf("store: unable to mark entries as read: %w", err)
 	}
-	return count, nil
+	return result.RowsAffected(), nil
 }
 
 // GetEntry returns a single entry that match the condition.
@@ -370,11 +480,17 @@ func (self *EntryQueryBuilder) GetEntries(ctx context.Context,
 			dest = append(dest, &rowNumber)
 		}
 
+		var snippet string
+		if self.searchArg != "" {
+			dest = append(dest, &snippet)
+		}
+
 		err := rows.Scan(dest...)
 		if err != nil {
 			return nil, fmt.Errorf("storage: unable to fetch entry row: %w", err)
 		}
 		dest = dest[:0]
+		entry.Snippet = model.SnippetFromHeadline(snippet)
 
 		if iconID.Valid && iconHash.Valid && iconHash.String != "" {
 			*entry.Feed.Icon = model.FeedIcon{
@@ -452,12 +568,12 @@ FROM entries e
 WHERE ` + self.buildCondition() + self.buildSorting()
 
 	if len(self.highConds) == 0 {
-		return body + self.buildLimitOffset()
+		return self.withSnippetField(body + self.buildLimitOffset())
 	}
 
-	return `
+	return self.withSnippetField(`
 WITH t AS (` + body + `) SELECT * FROM t
-WHERE ` + self.buildHighConds() + self.buildLimitOffset()
+WHERE ` + self.buildHighConds() + self.buildLimitOffset())
 }
 
 func (self *EntryQueryBuilder) withContentField() string {
@@ -467,6 +583,25 @@ func (self *EntryQueryBuilder) withContentField() string {
 	return ""
 }
 
+// withSnippetField wraps query, which is already limited, and appends the
+// snippet of the content matching the full-text search query, so
+// ts_headline() runs for returned rows only. Tags are removed first,
+// ts_headline() doesn't skip them.
+func (self *EntryQueryBuilder) withSnippetField(query string) string {
+	if self.searchArg == "" {
+		return query
+	}
+	return `
+SELECT l.*, (
+  SELECT ts_headline(entry_search_config(s.language),
+           regexp_replace(coalesce(s.content, ''), '<[^>]*>', ' ', 'g'),
+           entry_search_query($` + self.searchArg + `),
+           'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, ` +
+		`MinWords=10, FragmentDelimiter=" … "')
+    FROM entries s WHERE s.id = l.id) AS snippet
+  FROM (` + query + `) l`
+}
+
 func (self *EntryQueryBuilder) withRowNumberField() string {
 	if self.numberedRows {
 		return `, row_number() OVER(` + self.buildSorting() + `) AS row_number`
@@ -485,7 +620,9 @@ func (self *EntryQueryBuilder) buildHighConds() string {
 func (self *EntryQueryBuilder) GetEntryIDs(ctx context.Context) ([]int64, error) {
 	rows, _ := self.db.Query(ctx, `
 SELECT e.id
-  FROM entries e LEFT JOIN feeds f ON f.id=e.feed_id
+  FROM entries e
+       JOIN feeds f ON f.id = e.feed_id
+       JOIN categories c ON c.id = f.category_id
  WHERE `+self.buildCondition()+self.buildSorting()+self.buildLimitOffset(),
 		self.args...)
 
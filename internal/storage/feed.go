@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 
 	"github.com/jackc/pgx/v5"
@@ -212,6 +213,15 @@ func (s *Storage) FeedsByCategoryWithCounters(ctx context.Context,
 	return getFeedsSorted(ctx, builder)
 }
 
+// FeedsByCategory returns all feeds of the given user/category.
+func (s *Storage) FeedsByCategory(ctx context.Context, userID, categoryID int64,
+) (model.Feeds, error) {
+	builder := s.NewFeedQueryBuilder(userID).
+		WithCategoryID(categoryID).
+		WithSorting(model.DefaultFeedSorting, model.DefaultFeedSortingDirection)
+	return builder.GetFeeds(ctx)
+}
+
 // FeedByID returns a feed by the ID.
 func (s *Storage) FeedByID(ctx context.Context, userID, feedID int64,
 ) (*model.Feed, error) {
@@ -224,6 +234,20 @@ func (s *Storage) FeedByID(ctx context.Context, userID, feedID int64,
 	return feed, nil
 }
 
+// FeedsByIDs returns feeds of the user with given IDs.
+func (s *Storage) FeedsByIDs(ctx context.Context, userID int64, feedIDs []int64,
+) (model.Feeds, error) {
+	feeds, err := s.NewFeedQueryBuilder(userID).
+		WithFeedIDs(feedIDs).
+		WithSorting(model.DefaultFeedSorting, model.DefaultFeedSortingDirection).
+		GetFeeds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch feeds %v: %w",
+			feedIDs, err)
+	}
+	return feeds, nil
+}
+
 // CreateFeed creates a new feed.
 func (s *Storage) CreateFeed(ctx context.Context, feed *model.Feed) error {
 	if err := s.createFeed(ctx, feed); err != nil {
@@ -312,9 +336,7 @@ RETURNING id`,
 	return nil
 }
 
-// UpdateFeed updates an existing feed.
-func (s *Storage) UpdateFeed(ctx context.Context, feed *model.Feed) error {
-	_, err := s.db.Exec(ctx, `
+const updateFeedSQL = `
 UPDATE feeds
 SET
 	feed_url = $1,
@@ -346,7 +368,10 @@ SET
 	pushover_priority = $27,
 	proxy_url = $28,
   extra = $29
-WHERE id = $30 AND user_id = $31`,
+WHERE id = $30 AND user_id = $31`
+
+func updateFeedArgs(feed *model.Feed) []any {
+	return []any{
 		feed.FeedURL,
 		feed.SiteURL,
 		feed.Title,
@@ -376,7 +401,13 @@ WHERE id = $30 AND user_id = $31`,
 		feed.PushoverPriority,
 		feed.ProxyURL,
 		&feed.Extra,
-		feed.ID, feed.UserID)
+		feed.ID, feed.UserID,
+	}
+}
+
+// UpdateFeed updates an existing feed.
+func (s *Storage) UpdateFeed(ctx context.Context, feed *model.Feed) error {
+	_, err := s.db.Exec(ctx, updateFeedSQL, updateFeedArgs(feed)...)
 	if err != nil {
 		return fmt.Errorf("storage: unable to update feed #%d (%s): %w",
 			feed.ID, feed.FeedURL, err)
@@ -384,6 +415,38 @@ WHERE id = $30 AND user_id = $31`,
 	return nil
 }
 
+// UpdateFeeds applies the same changes to feeds of the user with given IDs,
+// all of them in a single transaction, and returns updated feeds. It returns
+// nil and doesn't change anything, if one of the feeds doesn't exist.
+func (s *Storage) UpdateFeeds(ctx context.Context, userID int64,
+	r *model.FeedsModificationRequest,
+) (model.Feeds, error) {
+	feedIDs := slices.Compact(slices.Sorted(slices.Values(r.FeedIDs)))
+	feeds, err := s.FeedsByIDs(ctx, userID, feedIDs)
+	if err != nil {
+		return nil, err
+	} else if len(feeds) != len(feedIDs) {
+		return nil, nil
+	}
+
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		for _, feed := range feeds {
+			r.Changes.Patch(feed)
+			_, err := tx.Exec(ctx, updateFeedSQL, updateFeedArgs(feed)...)
+			if err != nil {
+				return fmt.Errorf("update feed #%d (%s): %w", feed.ID, feed.FeedURL,
+					err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to update feeds %v: %w", feedIDs,
+			err)
+	}
+	return s.FeedsByIDs(ctx, userID, feedIDs)
+}
+
 func (s *Storage) UpdateFeedRuntime(ctx context.Context, feed *model.Feed,
 ) error {
 	_, err := s.db.Exec(ctx, `
@@ -462,6 +525,9 @@ func (s *Storage) RemoveFeed(ctx context.Context, userID, feedID int64) (bool,
 	if err != nil {
 		return false, fmt.Errorf("storage: unable to delete feed #%d: %w", feedID,
 			err)
+	} else if affected {
+		s.events.Publish(userID, model.NewStatusEvent(model.EntryStatusRemoved).
+			WithFeedID(feedID))
 	}
 	return affected, nil
 }
@@ -493,6 +559,11 @@ func (s *Storage) RemoveMultipleFeeds(ctx context.Context, userID int64,
 		return fmt.Errorf("storage: unable to delete multiple feeds(%d): %w",
 			len(feedIDs), err)
 	}
+
+	for _, feedID := range feedIDs {
+		s.events.Publish(userID, model.NewStatusEvent(model.EntryStatusRemoved).
+			WithFeedID(feedID))
+	}
 	return nil
 }
 
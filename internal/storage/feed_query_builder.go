@@ -57,6 +57,14 @@ func (f *FeedQueryBuilder) WithCategoryID(categoryID int64) *FeedQueryBuilder {
 	return f
 }
 
+// WithFeedIDs filter by feed IDs.
+func (f *FeedQueryBuilder) WithFeedIDs(feedIDs []int64) *FeedQueryBuilder {
+	f.conditions = append(f.conditions,
+		"f.id = ANY($"+strconv.Itoa(len(f.args)+1)+")")
+	f.args = append(f.args, feedIDs)
+	return f
+}
+
 // WithCounters let the builder return feeds with counters of statuses of
 // entries.
 func (f *FeedQueryBuilder) WithCounters() *FeedQueryBuilder {
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// IncFilterRuleHits adds hits of block and keep rules to counters of the feed.
+func (s *Storage) IncFilterRuleHits(ctx context.Context, userID, feedID int64,
+	hits map[model.FilterRuleKey]int,
+) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	actions := make([]string, 0, len(hits))
+	rules := make([]string, 0, len(hits))
+	counts := make([]int64, 0, len(hits))
+	for k, n := range hits {
+		actions = append(actions, string(k.Action))
+		rules = append(rules, k.Rule)
+		counts = append(counts, int64(n))
+	}
+
+	_, err := s.db.Exec(ctx, `
+INSERT INTO filter_rule_hits (user_id, feed_id, action, rule, hits)
+SELECT $1, $2, t.action, t.rule, t.hits
+  FROM unnest($3::text[], $4::text[], $5::bigint[]) AS t(action, rule, hits)
+ON CONFLICT (user_id, feed_id, action, rule)
+  DO UPDATE SET hits = filter_rule_hits.hits + excluded.hits,
+                last_hit_at = now()`,
+		userID, feedID, actions, rules, counts)
+	if err != nil {
+		return fmt.Errorf("storage: unable to increment filter rule hits: %w", err)
+	}
+	return nil
+}
+
+// FilterRuleHits returns hits of block and keep rules of the user for every
+// feed.
+func (s *Storage) FilterRuleHits(ctx context.Context, userID int64,
+) ([]model.FilterRuleHit, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT feed_id, action, rule, hits, last_hit_at
+  FROM filter_rule_hits
+ WHERE user_id = $1
+ ORDER BY feed_id, action, rule`, userID)
+
+	hits, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.FilterRuleHit])
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch filter rule hits: %w", err)
+	}
+	return hits, nil
+}
+
+// FeedFilterRuleHits returns hits of block and keep rules for the given feed,
+// including rules inherited from the user and the category.
+func (s *Storage) FeedFilterRuleHits(ctx context.Context, userID,
+	feedID int64,
+) ([]model.FilterRuleHit, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT feed_id, action, rule, hits, last_hit_at
+  FROM filter_rule_hits
+ WHERE user_id = $1 AND feed_id = $2
+ ORDER BY action, rule`, userID, feedID)
+
+	hits, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.FilterRuleHit])
+	if err != nil {
+		return nil, fmt.Errorf(
+			"storage: unable to fetch filter rule hits of feed: %w", err)
+	}
+	return hits, nil
+}
@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"miniflux.app/v2/internal/logging"
+	"miniflux.app/v2/internal/model"
+)
+
+const labelColumns = `l.id, l.user_id, l.title, l.created_at,
+       (SELECT count(*) FROM entry_labels el WHERE el.label_id = l.id)::int
+         AS entry_count`
+
+// Labels returns all labels of the user.
+func (s *Storage) Labels(ctx context.Context, userID int64) ([]model.Label,
+	error,
+) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+labelColumns+`
+  FROM labels l
+ WHERE l.user_id = $1
+ ORDER BY lower(l.title) ASC`, userID)
+
+	labels, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.Label])
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch labels: %w", err)
+	}
+	return labels, nil
+}
+
+// LabelByID returns a label of the user.
+func (s *Storage) LabelByID(ctx context.Context, userID, labelID int64,
+) (*model.Label, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+labelColumns+`
+  FROM labels l
+ WHERE l.user_id = $1 AND l.id = $2`, userID, labelID)
+	return collectLabel(rows)
+}
+
+// LabelByTitle finds a label of the user by its case insensitive title.
+func (s *Storage) LabelByTitle(ctx context.Context, userID int64,
+	title string,
+) (*model.Label, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+labelColumns+`
+  FROM labels l
+ WHERE l.user_id = $1 AND lower(l.title) = lower($2)`, userID, title)
+	return collectLabel(rows)
+}
+
+func collectLabel(rows pgx.Rows) (*model.Label, error) {
+	label, err := pgx.CollectExactlyOneRow(rows,
+		pgx.RowToAddrOfStructByName[model.Label])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch label: %w", err)
+	}
+	return label, nil
+}
+
+// AnotherLabelExists checks if another label of the user has the same title.
+func (s *Storage) AnotherLabelExists(ctx context.Context, userID,
+	labelID int64, title string,
+) bool {
+	rows, _ := s.db.Query(ctx, `
+SELECT EXISTS (
+  SELECT FROM labels
+   WHERE user_id = $1 AND id != $2 AND lower(title) = lower($3))`,
+		userID, labelID, title)
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[bool])
+	if err != nil {
+		logging.FromContext(ctx).Error("failed label lookup",
+			slog.Int64("user_id", userID),
+			slog.Int64("label_id", labelID),
+			slog.String("title", title),
+			slog.Any("error", err))
+		return false
+	}
+	return result
+}
+
+// CreateLabel creates a new label.
+func (s *Storage) CreateLabel(ctx context.Context, userID int64,
+	title string,
+) (*model.Label, error) {
+	rows, _ := s.db.Query(ctx, `
+INSERT INTO labels (user_id, title) VALUES ($1, $2)
+RETURNING id, user_id, title, created_at, 0 AS entry_count`,
+		userID, title)
+
+	label, err := pgx.CollectExactlyOneRow(rows,
+		pgx.RowToAddrOfStructByName[model.Label])
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to create label %q: %w", title,
+			err)
+	}
+	return label, nil
+}
+
+// UpdateLabel renames a label.
+func (s *Storage) UpdateLabel(ctx context.Context, label *model.Label,
+) (bool, error) {
+	result, err := s.db.Exec(ctx,
+		`UPDATE labels SET title = $3 WHERE id = $1 AND user_id = $2`,
+		label.ID, label.UserID, label.Title)
+	if err != nil {
+		return false, fmt.Errorf("storage: unable to update label: %w", err)
+	}
+	return result.RowsAffected() != 0, nil
+}
+
+// RemoveLabel deletes a label and removes it from all entries.
+func (s *Storage) RemoveLabel(ctx context.Context, userID, labelID int64,
+) (bool, error) {
+	result, err := s.db.Exec(ctx,
+		`DELETE FROM labels WHERE id = $1 AND user_id = $2`, labelID, userID)
+	if err != nil {
+		return false, fmt.Errorf("storage: unable to remove label: %w", err)
+	}
+	return result.RowsAffected() != 0, nil
+}
+
+// SetEntryLabels replaces labels of the entry. Labels of other users are
+// ignored. It returns false if the entry doesn't exist.
+func (s *Storage) SetEntryLabels(ctx context.Context, userID, entryID int64,
+	labelIDs []int64,
+) (bool, error) {
+	if labelIDs == nil {
+		labelIDs = []int64{}
+	}
+
+	var found bool
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		rows, _ := tx.Query(ctx, `
+SELECT EXISTS (SELECT FROM entries WHERE user_id = $1 AND id = $2)`,
+			userID, entryID)
+		exists, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[bool])
+		if err != nil {
+			return fmt.Errorf("lookup entry: %w", err)
+		} else if !exists {
+			return nil
+		}
+		found = true
+
+		_, err = tx.Exec(ctx, `
+DELETE FROM entry_labels
+ WHERE entry_id = $1 AND NOT (label_id = ANY($2))`, entryID, labelIDs)
+		if err != nil {
+			return fmt.Errorf("delete entry labels: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+INSERT INTO entry_labels (entry_id, label_id)
+SELECT $2, id FROM labels WHERE user_id = $1 AND id = ANY($3)
+ON CONFLICT DO NOTHING`, userID, entryID, labelIDs)
+		if err != nil {
+			return fmt.Errorf("insert entry labels: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("storage: unable to set entry labels: %w", err)
+	}
+	return found, nil
+}
+
+// AddEntriesLabel adds the label to entries of the user.
+func (s *Storage) AddEntriesLabel(ctx context.Context, userID, labelID int64,
+	entryIDs []int64,
+) error {
+	_, err := s.db.Exec(ctx, `
+INSERT INTO entry_labels (entry_id, label_id)
+SELECT e.id, l.id
+  FROM entries e, labels l
+ WHERE e.user_id = $1 AND e.id = ANY($3) AND l.user_id = $1 AND l.id = $2
+ON CONFLICT DO NOTHING`, userID, labelID, entryIDs)
+	if err != nil {
+		return fmt.Errorf("storage: unable to add label to entries: %w", err)
+	}
+	return nil
+}
+
+// RemoveEntriesLabels removes labels from entries of the user. All labels are
+// removed when labelIDs is empty.
+func (s *Storage) RemoveEntriesLabels(ctx context.Context, userID int64,
+	entryIDs []int64, labelIDs ...int64,
+) error {
+	query := `
+DELETE FROM entry_labels el
+ USING labels l
+ WHERE l.id = el.label_id AND l.user_id = $1 AND el.entry_id = ANY($2)`
+	args := []any{userID, entryIDs}
+	if len(labelIDs) != 0 {
+		query += ` AND el.label_id = ANY($3)`
+		args = append(args, labelIDs)
+	}
+
+	if _, err := s.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("storage: unable to remove labels from entries: %w",
+			err)
+	}
+	return nil
+}
+
+// EntryLabels returns labels of the given entries, by entry ID.
+func (s *Storage) EntryLabels(ctx context.Context, userID int64,
+	entryIDs []int64,
+) (map[int64][]model.Label, error) {
+	result := make(map[int64][]model.Label)
+	if len(entryIDs) == 0 {
+		return result, nil
+	}
+
+	rows, _ := s.db.Query(ctx, `
+SELECT el.entry_id, l.id, l.user_id, l.title, l.created_at
+  FROM entry_labels el
+  JOIN labels l ON l.id = el.label_id
+ WHERE l.user_id = $1 AND el.entry_id = ANY($2)
+ ORDER BY lower(l.title) ASC`, userID, entryIDs)
+
+	var entryID int64
+	var label model.Label
+	_, err := pgx.ForEachRow(rows,
+		[]any{&entryID, &label.ID, &label.UserID, &label.Title, &label.CreatedAt},
+		func() error {
+			result[entryID] = append(result[entryID], label)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch entry labels: %w", err)
+	}
+	return result, nil
+}
+
+// MarkLabelAsRead updates all unread entries with the label to the status
+// read, when published before the given date.
+func (s *Storage) MarkLabelAsRead(ctx context.Context, userID, labelID int64,
+	before time.Time,
+) error {
+	result, err := s.db.Exec(ctx, `
+UPDATE entries
+   SET status=$1, changed_at=now()
+ WHERE user_id=$2 AND status=$3 AND published_at < $4
+       AND id IN (SELECT entry_id FROM entry_labels WHERE label_id=$5)`,
+		model.EntryStatusRead, userID, model.EntryStatusUnread, before, labelID)
+	if err != nil {
+		return fmt.Errorf("storage: unable to mark label entries as read: %w",
+			err)
+	}
+
+	logging.FromContext(ctx).Debug("Marked label entries as read",
+		slog.Int64("user_id", userID),
+		slog.Int64("label_id", labelID),
+		slog.Int64("nb_entries", result.RowsAffected()),
+		slog.String("before", before.Format(time.RFC3339)))
+	return nil
+}
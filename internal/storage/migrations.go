@@ -770,4 +770,120 @@ ALTER TABLE webauthn_credentials
 	// 130
 	sqlMigration(`
 DROP INDEX IF EXISTS entries_feed_idx, entries_user_status_idx`),
+
+	// 131
+	sqlMigration(`
+CREATE TABLE filter_rule_hits (
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  feed_id bigint NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+  action text NOT NULL,
+  rule text NOT NULL,
+  hits bigint NOT NULL DEFAULT 0,
+  last_hit_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, feed_id, action, rule)
+);
+CREATE INDEX ON filter_rule_hits (feed_id);`),
+
+	// 132
+	sqlMigration(`
+CREATE TABLE labels (
+  id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  title text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX ON labels (user_id, lower(title));
+CREATE TABLE entry_labels (
+  entry_id bigint NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+  label_id bigint NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (entry_id, label_id)
+);
+CREATE INDEX ON entry_labels (label_id);`),
+
+	// 133
+	sqlMigration(`
+DO $do$
+DECLARE
+  languages text;
+  queries text;
+BEGIN
+  WITH l(code, name) AS (VALUES
+    ('ar', 'arabic'), ('hy', 'armenian'), ('eu', 'basque'),
+    ('ca', 'catalan'), ('da', 'danish'), ('nl', 'dutch'), ('en', 'english'),
+    ('fi', 'finnish'), ('fr', 'french'), ('de', 'german'), ('el', 'greek'),
+    ('hi', 'hindi'), ('hu', 'hungarian'), ('id', 'indonesian'),
+    ('ga', 'irish'), ('it', 'italian'), ('lt', 'lithuanian'),
+    ('ne', 'nepali'), ('no', 'norwegian'), ('nb', 'norwegian'),
+    ('nn', 'norwegian'), ('pt', 'portuguese'), ('ro', 'romanian'),
+    ('ru', 'russian'), ('sr', 'serbian'), ('es', 'spanish'),
+    ('sv', 'swedish'), ('ta', 'tamil'), ('tr', 'turkish'), ('yi', 'yiddish'))
+  SELECT string_agg(format('WHEN %L THEN %L::regconfig', l.code, l.name), ' '),
+         string_agg(DISTINCT format(
+           ' || websearch_to_tsquery(%L::regconfig, query)', l.name), '')
+    INTO languages, queries
+    FROM l
+    JOIN pg_ts_config c ON c.cfgname = l.name
+   WHERE c.cfgnamespace = 'pg_catalog'::regnamespace;
+
+  EXECUTE format($f$
+CREATE FUNCTION entry_search_config(language text) RETURNS regconfig
+  LANGUAGE sql IMMUTABLE PARALLEL SAFE
+  AS $$ SELECT CASE split_part(language, '-', 1) %s
+               ELSE 'simple'::regconfig END $$$f$,
+    coalesce(languages, ''));
+
+  EXECUTE format($f$
+CREATE FUNCTION entry_search_query(query text) RETURNS tsquery
+  LANGUAGE sql IMMUTABLE PARALLEL SAFE
+  AS $$ SELECT websearch_to_tsquery('simple', query)%s $$$f$,
+    coalesce(queries, ''));
+END
+$do$;
+
+ALTER TABLE entries ADD COLUMN language text NOT NULL DEFAULT '';
+UPDATE entries e
+   SET language = coalesce(nullif(e.extra->>'language', ''),
+                           f.runtime->>'language', '')
+  FROM feeds f
+ WHERE f.id = e.feed_id
+   AND coalesce(nullif(e.extra->>'language', ''),
+                f.runtime->>'language', '') <> '';
+
+ALTER TABLE entries
+  DROP COLUMN document_vectors,
+  ADD  COLUMN document_vectors tsvector GENERATED ALWAYS AS (
+      setweight(to_tsvector(entry_search_config(language),
+        left(coalesce(title,   ''), 500000)), 'A') ||
+      setweight(to_tsvector(entry_search_config(language),
+        left(coalesce(content, ''), 500000)), 'B')
+  ) STORED;
+CREATE INDEX ON entries
+ USING gin (document_vectors) WHERE status != 'removed';`),
+
+	// 134
+	sqlMigration(`
+CREATE TABLE saved_searches (
+  id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  title text NOT NULL,
+  query text NOT NULL,
+  token text NOT NULL UNIQUE,
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX ON saved_searches (user_id, lower(title));`),
+
+	// 135
+	sqlMigration(`
+CREATE TABLE site_rules (
+  id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  user_id integer REFERENCES users(id) ON DELETE CASCADE,
+  hostname text NOT NULL,
+  scraper_rules text NOT NULL DEFAULT '',
+  rewrite_rules text NOT NULL DEFAULT '',
+  referer text NOT NULL DEFAULT '',
+  user_agent text NOT NULL DEFAULT '',
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX ON site_rules ((coalesce(user_id, 0)), hostname);`),
 }
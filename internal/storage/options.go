@@ -1,5 +1,7 @@
 package storage
 
+import "miniflux.app/v2/internal/events"
+
 type Option func(s *Storage)
 
 func WithNewDedup() Option {
@@ -16,3 +18,12 @@ func (s *Storage) WithDedup(d *DedupEntries) *Storage {
 }
 
 func (s *Storage) DedupEntries() *DedupEntries { return s.dedup }
+
+// WithEvents sets the broker of events about changed entries.
+func (s *Storage) WithEvents(b *events.Broker) *Storage {
+	s.events = b
+	return s
+}
+
+// Events returns the broker of events about changed entries, which may be nil.
+func (s *Storage) Events() *events.Broker { return s.events }
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/logging"
+	"miniflux.app/v2/internal/model"
+)
+
+const savedSearchColumns = `id, user_id, title, query, token, created_at`
+
+// SavedSearches returns all saved searches of the user.
+func (s *Storage) SavedSearches(ctx context.Context, userID int64,
+) ([]model.SavedSearch, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+savedSearchColumns+`
+  FROM saved_searches
+ WHERE user_id = $1
+ ORDER BY lower(title) ASC`, userID)
+
+	searches, err := pgx.CollectRows(rows,
+		pgx.RowToStructByName[model.SavedSearch])
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch saved searches: %w", err)
+	}
+	return searches, nil
+}
+
+// SavedSearchesWithCounters returns all saved searches of the user with
+// their unread entries count. Dates of the queries are in the given location.
+func (s *Storage) SavedSearchesWithCounters(ctx context.Context,
+	userID int64, loc *time.Location,
+) ([]model.SavedSearch, error) {
+	searches, err := s.SavedSearches(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch pgx.Batch
+	for i := range searches {
+		search := &searches[i]
+		q, err := search.SearchQuery(loc)
+		if err != nil {
+			logging.FromContext(ctx).Warn("invalid saved search query",
+				slog.Int64("user_id", userID),
+				slog.Int64("saved_search_id", search.ID),
+				slog.Any("error", err))
+			continue
+		}
+
+		s.NewEntryQueryBuilder(userID).
+			WithSearch(q).
+			WithStatus(model.EntryStatusUnread).
+			queueCount(&batch, &search.UnreadCount)
+	}
+
+	if batch.Len() == 0 {
+		return searches, nil
+	} else if err := s.db.SendBatch(ctx, &batch).Close(); err != nil {
+		return nil, fmt.Errorf(
+			"storage: unable to count entries of saved searches(%d): %w",
+			batch.Len(), err)
+	}
+	return searches, nil
+}
+
+// SavedSearchByID returns a saved search of the user.
+func (s *Storage) SavedSearchByID(ctx context.Context, userID, id int64,
+) (*model.SavedSearch, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+savedSearchColumns+`
+  FROM saved_searches
+ WHERE user_id = $1 AND id = $2`, userID, id)
+	return collectSavedSearch(rows)
+}
+
+// SavedSearchByTitle finds a saved search of the user by its case
+// insensitive title.
+func (s *Storage) SavedSearchByTitle(ctx context.Context, userID int64,
+	title string,
+) (*model.SavedSearch, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+savedSearchColumns+`
+  FROM saved_searches
+ WHERE user_id = $1 AND lower(title) = lower($2)`, userID, title)
+	return collectSavedSearch(rows)
+}
+
+// SavedSearchByToken returns the saved search with the given feed token.
+func (s *Storage) SavedSearchByToken(ctx context.Context, token string,
+) (*model.SavedSearch, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+savedSearchColumns+`
+  FROM saved_searches
+ WHERE token = $1`, token)
+	return collectSavedSearch(rows)
+}
+
+func collectSavedSearch(rows pgx.Rows) (*model.SavedSearch, error) {
+	search, err := pgx.CollectExactlyOneRow(rows,
+		pgx.RowToAddrOfStructByName[model.SavedSearch])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch saved search: %w", err)
+	}
+	return search, nil
+}
+
+// AnotherSavedSearchExists checks if another saved search of the user has
+// the same title.
+func (s *Storage) AnotherSavedSearchExists(ctx context.Context, userID,
+	id int64, title string,
+) bool {
+	rows, _ := s.db.Query(ctx, `
+SELECT EXISTS (
+  SELECT FROM saved_searches
+   WHERE user_id = $1 AND id != $2 AND lower(title) = lower($3))`,
+		userID, id, title)
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[bool])
+	if err != nil {
+		logging.FromContext(ctx).Error("failed saved search lookup",
+			slog.Int64("user_id", userID),
+			slog.Int64("saved_search_id", id),
+			slog.String("title", title),
+			slog.Any("error", err))
+		return false
+	}
+	return result
+}
+
+// CreateSavedSearch creates a new saved search with a random feed token.
+func (s *Storage) CreateSavedSearch(ctx context.Context, userID int64,
+	r *model.SavedSearchRequest,
+) (*model.SavedSearch, error) {
+	rows, _ := s.db.Query(ctx, `
+INSERT INTO saved_searches (user_id, title, query, token)
+VALUES ($1, $2, $3, $4)
+RETURNING `+savedSearchColumns,
+		userID, r.Title, r.Query, crypto.GenerateRandomStringHex(20))
+
+	search, err := pgx.CollectExactlyOneRow(rows,
+		pgx.RowToAddrOfStructByName[model.SavedSearch])
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to create saved search %q: %w",
+			r.Title, err)
+	}
+	return search, nil
+}
+
+// UpdateSavedSearch updates title and query of a saved search.
+func (s *Storage) UpdateSavedSearch(ctx context.Context,
+	search *model.SavedSearch,
+) (bool, error) {
+	result, err := s.db.Exec(ctx, `
+UPDATE saved_searches SET title = $3, query = $4
+ WHERE id = $1 AND user_id = $2`,
+		search.ID, search.UserID, search.Title, search.Query)
+	if err != nil {
+		return false, fmt.Errorf("storage: unable to update saved search: %w",
+			err)
+	}
+	return result.RowsAffected() != 0, nil
+}
+
+// RemoveSavedSearch deletes a saved search.
+func (s *Storage) RemoveSavedSearch(ctx context.Context, userID, id int64,
+) (bool, error) {
+	result, err := s.db.Exec(ctx,
+		`DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("storage: unable to remove saved search: %w",
+			err)
+	}
+	return result.RowsAffected() != 0, nil
+}
+
+// MarkSavedSearchAsRead updates all unread entries matching the saved search
+// to the status read, when published before the given date.
+func (s *Storage) MarkSavedSearchAsRead(ctx context.Context, userID int64,
+	q *model.SearchQuery, before time.Time,
+) error {
+	affected, err := s.NewEntryQueryBuilder(userID).
+		WithSearch(q).
+		BeforePublishedDate(before).
+		MarkAsRead(ctx)
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Debug("Marked saved search entries as read",
+		slog.Int64("user_id", userID),
+		slog.Int64("nb_entries", affected),
+		slog.String("before", before.Format(time.RFC3339)))
+	return nil
+}
@@ -3,7 +3,7 @@
 CREATE TABLE schema_version (
     version text NOT NULL
 );
-INSERT INTO schema_version (version) VALUES('130');
+INSERT INTO schema_version (version) VALUES('135');
 
 CREATE TABLE acme_cache (
     key character varying(400) NOT NULL PRIMARY KEY,
@@ -152,6 +152,44 @@ CREATE TYPE entry_status AS ENUM (
     'removed'
 );
 
+DO $do$
+DECLARE
+  languages text;
+  queries text;
+BEGIN
+  WITH l(code, name) AS (VALUES
+    ('ar', 'arabic'), ('hy', 'armenian'), ('eu', 'basque'),
+    ('ca', 'catalan'), ('da', 'danish'), ('nl', 'dutch'), ('en', 'english'),
+    ('fi', 'finnish'), ('fr', 'french'), ('de', 'german'), ('el', 'greek'),
+    ('hi', 'hindi'), ('hu', 'hungarian'), ('id', 'indonesian'),
+    ('ga', 'irish'), ('it', 'italian'), ('lt', 'lithuanian'),
+    ('ne', 'nepali'), ('no', 'norwegian'), ('nb', 'norwegian'),
+    ('nn', 'norwegian'), ('pt', 'portuguese'), ('ro', 'romanian'),
+    ('ru', 'russian'), ('sr', 'serbian'), ('es', 'spanish'),
+    ('sv', 'swedish'), ('ta', 'tamil'), ('tr', 'turkish'), ('yi', 'yiddish'))
+  SELECT string_agg(format('WHEN %L THEN %L::regconfig', l.code, l.name), ' '),
+         string_agg(DISTINCT format(
+           ' || websearch_to_tsquery(%L::regconfig, query)', l.name), '')
+    INTO languages, queries
+    FROM l
+    JOIN pg_ts_config c ON c.cfgname = l.name
+   WHERE c.cfgnamespace = 'pg_catalog'::regnamespace;
+
+  EXECUTE format($f$
+CREATE FUNCTION entry_search_config(language text) RETURNS regconfig
+  LANGUAGE sql IMMUTABLE PARALLEL SAFE
+  AS $$ SELECT CASE split_part(language, '-', 1) %s
+               ELSE 'simple'::regconfig END $$$f$,
+    coalesce(languages, ''));
+
+  EXECUTE format($f$
+CREATE FUNCTION entry_search_query(query text) RETURNS tsquery
+  LANGUAGE sql IMMUTABLE PARALLEL SAFE
+  AS $$ SELECT websearch_to_tsquery('simple', query)%s $$$f$,
+    coalesce(queries, ''));
+END
+$do$;
+
 CREATE TABLE entries (
     id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
     user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
@@ -165,9 +203,12 @@ CREATE TABLE entries (
     status entry_status DEFAULT 'unread',
     starred boolean DEFAULT false,
     comments_url text DEFAULT '',
+    language text NOT NULL DEFAULT '',
     document_vectors tsvector GENERATED ALWAYS AS (
-      setweight(to_tsvector('simple', left(coalesce(title,   ''), 500000)), 'A') ||
-      setweight(to_tsvector('simple', left(coalesce(content, ''), 500000)), 'B')
+      setweight(to_tsvector(entry_search_config(language),
+        left(coalesce(title,   ''), 500000)), 'A') ||
+      setweight(to_tsvector(entry_search_config(language),
+        left(coalesce(content, ''), 500000)), 'B')
     ) STORED,
     changed_at timestamp with time zone NOT NULL,
     reading_time integer DEFAULT 0 NOT NULL,
@@ -213,3 +254,57 @@ CREATE TABLE webauthn_credentials (
     backup_eligible boolean,
     backup_state boolean NOT NULL DEFAULT false
 );
+
+CREATE TABLE filter_rule_hits (
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    feed_id bigint NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    action text NOT NULL,
+    rule text NOT NULL,
+    hits bigint NOT NULL DEFAULT 0,
+    last_hit_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, feed_id, action, rule)
+);
+
+CREATE INDEX ON filter_rule_hits (feed_id);
+
+CREATE TABLE labels (
+    id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX ON labels (user_id, lower(title));
+
+CREATE TABLE entry_labels (
+    entry_id bigint NOT NULL REFERENCES entries(id) ON DELETE CASCADE,
+    label_id bigint NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (entry_id, label_id)
+);
+
+CREATE INDEX ON entry_labels (label_id);
+
+CREATE TABLE saved_searches (
+    id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title text NOT NULL,
+    query text NOT NULL,
+    token text NOT NULL UNIQUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX ON saved_searches (user_id, lower(title));
+
+CREATE TABLE site_rules (
+    id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE,
+    hostname text NOT NULL,
+    scraper_rules text NOT NULL DEFAULT '',
+    rewrite_rules text NOT NULL DEFAULT '',
+    referer text NOT NULL DEFAULT '',
+    user_agent text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX ON site_rules ((coalesce(user_id, 0)), hostname);
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+
+	"miniflux.app/v2/internal/logging"
+	"miniflux.app/v2/internal/model"
+)
+
+// Global site rules have NULL user_id. Functions below use zero instead of it.
+const siteRuleColumns = `
+id, coalesce(user_id, 0) AS user_id, hostname, scraper_rules, rewrite_rules,
+referer, user_agent, created_at`
+
+// SiteRules returns site rules of the user and global site rules.
+func (s *Storage) SiteRules(ctx context.Context, userID int64,
+) (model.SiteRules, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+siteRuleColumns+`
+  FROM site_rules
+ WHERE user_id = $1 OR user_id IS NULL
+ ORDER BY hostname ASC, user_id ASC NULLS LAST`, userID)
+	return collectSiteRules(rows)
+}
+
+// SiteRulesByOwner returns site rules of the user or global site rules, when
+// userID is zero.
+func (s *Storage) SiteRulesByOwner(ctx context.Context, userID int64,
+) (model.SiteRules, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+siteRuleColumns+`
+  FROM site_rules
+ WHERE coalesce(user_id, 0) = $1
+ ORDER BY hostname ASC`, userID)
+	return collectSiteRules(rows)
+}
+
+func collectSiteRules(rows pgx.Rows) (model.SiteRules, error) {
+	rules, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.SiteRule])
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch site rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SiteRuleByID returns a site rule of the user or a global site rule.
+func (s *Storage) SiteRuleByID(ctx context.Context, userID, id int64,
+) (*model.SiteRule, error) {
+	rows, _ := s.db.Query(ctx, `
+SELECT `+siteRuleColumns+`
+  FROM site_rules
+ WHERE id = $2 AND (user_id = $1 OR user_id IS NULL)`, userID, id)
+
+	rule, err := pgx.CollectExactlyOneRow(rows,
+		pgx.RowToAddrOfStructByName[model.SiteRule])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("storage: unable to fetch site rule: %w", err)
+	}
+	return rule, nil
+}
+
+// AnotherSiteRuleExists checks if another site rule of the user, or another
+// global site rule, when userID is zero, has the same hostname.
+func (s *Storage) AnotherSiteRuleExists(ctx context.Context, userID, id int64,
+	hostname string,
+) bool {
+	rows, _ := s.db.Query(ctx, `
+SELECT EXISTS (
+  SELECT FROM site_rules
+   WHERE coalesce(user_id, 0) = $1 AND id != $2 AND hostname = $3)`,
+		userID, id, hostname)
+
+	result, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[bool])
+	if err != nil {
+		logging.FromContext(ctx).Error("failed site rule lookup",
+			slog.Int64("user_id", userID),
+			slog.Int64("site_rule_id", id),
+			slog.String("hostname", hostname),
+			slog.Any("error", err))
+		return false
+	}
+	return result
+}
+
+// CreateSiteRule creates a new site rule of the user or a global one.
+func (s *Storage) CreateSiteRule(ctx context.Context, userID int64,
+	r *model.SiteRuleRequest,
+) (*model.SiteRule, error) {
+	rows, _ := s.db.Query(ctx, `
+INSERT INTO site_rules
+  (user_id, hostname, scraper_rules, rewrite_rules, referer, user_agent)
+VALUES (nullif($1, 0), $2, $3, $4, $5, $6)
+RETURNING `+siteRuleColumns,
+		r.UserID(userID), r.Hostname, r.ScraperRules, r.RewriteRules, r.Referer,
+		r.UserAgent)
+
+	rule, err := pgx.CollectExactlyOneRow(rows,
+		pgx.RowToAddrOfStructByName[model.SiteRule])
+	if err != nil {
+		return nil, fmt.Errorf("storage: unable to create site rule %q: %w",
+			r.Hostname, err)
+	}
+	return rule, nil
+}
+
+// UpdateSiteRule updates a site rule. The owner of the site rule can't be
+// changed.
+func (s *Storage) UpdateSiteRule(ctx context.Context, rule *model.SiteRule,
+) (bool, error) {
+	result, err := s.db.Exec(ctx, `
+UPDATE site_rules
+   SET hostname = $3, scraper_rules = $4, rewrite_rules = $5, referer = $6,
+       user_agent = $7
+ WHERE id = $1 AND coalesce(user_id, 0) = $2`,
+		rule.ID, rule.UserID, rule.Hostname, rule.ScraperRules,
+		rule.RewriteRules, rule.Referer, rule.UserAgent)
+	if err != nil {
+		return false, fmt.Errorf("storage: unable to update site rule: %w", err)
+	}
+	return result.RowsAffected() != 0, nil
+}
+
+// RemoveSiteRule deletes a site rule of the user or a global site rule, when
+// userID is zero.
+func (s *Storage) RemoveSiteRule(ctx context.Context, userID, id int64,
+) (bool, error) {
+	result, err := s.db.Exec(ctx, `
+DELETE FROM site_rules WHERE id = $1 AND coalesce(user_id, 0) = $2`,
+		id, userID)
+	if err != nil {
+		return false, fmt.Errorf("storage: unable to remove site rule: %w", err)
+	}
+	return result.RowsAffected() != 0, nil
+}
+
+// ImportSiteRules creates site rules of the user or global site rules, when
+// userID is zero. Existing site rules with the same hostname are replaced.
+func (s *Storage) ImportSiteRules(ctx context.Context, userID int64,
+	rules []model.SiteRuleRequest,
+) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var batch pgx.Batch
+	for i := range rules {
+		r := &rules[i]
+		batch.Queue(`
+INSERT INTO site_rules
+  (user_id, hostname, scraper_rules, rewrite_rules, referer, user_agent)
+VALUES (nullif($1, 0), $2, $3, $4, $5, $6)
+ON CONFLICT ((coalesce(user_id, 0)), hostname) DO UPDATE
+   SET scraper_rules = excluded.scraper_rules,
+       rewrite_rules = excluded.rewrite_rules,
+       referer       = excluded.referer,
+       user_agent    = excluded.user_agent`,
+			userID, r.Hostname, r.ScraperRules, r.RewriteRules, r.Referer,
+			r.UserAgent)
+	}
+
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, &batch).Close()
+	})
+	if err != nil {
+		return fmt.Errorf("storage: unable to import site rules(%d): %w",
+			len(rules), err)
+	}
+	return nil
+}
@@ -12,13 +12,15 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"miniflux.app/v2/internal/events"
 	"miniflux.app/v2/internal/logging"
 )
 
 // Storage handles all operations related to the database.
 type Storage struct {
-	db    *pgxpool.Pool
-	dedup *DedupEntries
+	db     *pgxpool.Pool
+	dedup  *DedupEntries
+	events *events.Broker
 }
 
 // New returns a new Storage.
@@ -50,6 +50,10 @@ func convert(tz string, t *time.Time) time.Time {
 // Now returns the current time in the given timezone.
 func Now(tz string) time.Time { return time.Now().In(getLocation(tz)) }
 
+// Location returns the location of the given timezone, or UTC if it's
+// invalid.
+func Location(tz string) *time.Location { return getLocation(tz) }
+
 func getLocation(tz string) *time.Location { return locations.Location(tz) }
 
 var locations = newLocationCache()
@@ -36,6 +36,7 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 		HideGlobally: category.HideGlobally,
 		HideLabel:    category.HiddenLabel(),
 		BlockFilter:  category.BlockFilter(),
+		Retention:    category.Extra.Retention,
 	}
 
 	v.Set("menu", "categories").
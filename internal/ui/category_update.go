@@ -21,6 +21,7 @@ func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
 		HideGlobally: new(f.HideGlobally),
 		HideLabel:    new(f.HideLabel),
 		BlockFilter:  new(f.BlockFilter),
+		Retention:    new(f.Retention),
 	}
 	userID := request.UserID(r)
 	id := request.RouteInt64Param(r, "categoryID")
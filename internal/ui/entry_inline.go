@@ -17,11 +17,32 @@ import (
 )
 
 func (h *handler) inlineEntry(w http.ResponseWriter, r *http.Request) {
-	entry, err := h.store.NewEntryQueryBuilder(request.UserID(r)).
-		WithEntryID(request.RouteInt64Param(r, "entryID")).
-		WithoutStatus(model.EntryStatusRemoved).
-		GetEntry(r.Context())
-	if err != nil {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+	g, ctx := errgroup.WithContext(r.Context())
+
+	var entry *model.Entry
+	g.Go(func() (err error) {
+		entry, err = h.store.NewEntryQueryBuilder(userID).
+			WithEntryID(entryID).
+			WithoutStatus(model.EntryStatusRemoved).
+			GetEntry(ctx)
+		return err
+	})
+
+	var labels []model.Label
+	g.Go(func() (err error) {
+		labels, err = h.store.Labels(ctx, userID)
+		return err
+	})
+
+	var entryLabels map[int64][]model.Label
+	g.Go(func() (err error) {
+		entryLabels, err = h.store.EntryLabels(ctx, userID, []int64{entryID})
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
 		response.ServerError(w, r, err)
 		return
 	} else if entry == nil {
@@ -29,6 +50,11 @@ func (h *handler) inlineEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	labeled := make(map[int64]bool, len(entryLabels[entryID]))
+	for _, label := range entryLabels[entryID] {
+		labeled[label.ID] = true
+	}
+
 	var errorMsg string
 	content := entry.Content
 	b, err := sites.Render(r.Context(), request.User(r), entry, h.tpl)
@@ -44,6 +70,8 @@ func (h *handler) inlineEntry(w http.ResponseWriter, r *http.Request) {
 	v := view.New(h.tpl, r).WithEntry(entry).
 		Set("errorMessage", errorMsg).
 		Set("safeContent", template.HTML(content)).
+		Set("labels", labels).
+		Set("entryLabels", labeled).
 		Set("user", request.User(r))
 	response.HTML(w, r, v.Render("entry_inline"))
 }
@@ -77,8 +105,8 @@ func (h *handler) downloadEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := processor.ProcessEntryWebPage(r.Context(), feed, entry, user,
-		sanitizer.WithRewriteURL(mediaproxy.New(h.router).RewriteURL))
+	err := processor.ProcessEntryWebPage(r.Context(), h.store, feed, entry,
+		user, sanitizer.WithRewriteURL(mediaproxy.New(h.router).RewriteURL))
 	if err != nil {
 		response.ServerError(w, r, err)
 		return
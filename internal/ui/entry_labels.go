@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) updateEntryLabels(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		response.BadRequest(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	user := request.User(r)
+	labelIDs := make([]int64, 0, len(r.PostForm["label_id"]))
+	for _, s := range r.PostForm["label_id"] {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			response.BadRequest(w, r, err)
+			return
+		}
+		labelIDs = append(labelIDs, id)
+	}
+
+	if title := strings.TrimSpace(r.PostFormValue("new_label")); title != "" {
+		label, err := h.store.LabelByTitle(ctx, user.ID, title)
+		if err != nil {
+			response.ServerError(w, r, err)
+			return
+		} else if label == nil {
+			labelRequest := model.LabelRequest{Title: title}
+			lerr := validator.ValidateLabel(ctx, h.store, user.ID, 0,
+				&labelRequest)
+			if lerr != nil {
+				response.BadRequest(w, r, lerr.Error())
+				return
+			}
+
+			label, err = h.store.CreateLabel(ctx, user.ID, labelRequest.Title)
+			if err != nil {
+				response.ServerError(w, r, err)
+				return
+			}
+		}
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	entryID := request.RouteInt64Param(r, "entryID")
+	found, err := h.store.SetEntryLabels(ctx, user.ID, entryID, labelIDs)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if !found {
+		response.NotFound(w, r)
+		return
+	}
+
+	// The form is submitted from entry lists, go back to the same list.
+	if u, err := url.Parse(r.Referer()); err == nil && u.Host == r.Host {
+		response.Redirect(w, r, u.RequestURI())
+		return
+	}
+	h.redirect(w, r, user.DefaultHomePage)
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/events"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/logging"
+	"miniflux.app/v2/internal/model"
+)
+
+// unreadCounterDelay is the time changes of entries are collected, before
+// the number of unread entries is counted and sent once for all of them.
+const unreadCounterDelay = time.Second
+
+// streamEvents streams the number of unread entries after changes of entries
+// of the user, so pages update their unread counter without reload.
+func (h *handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	broker := h.store.Events()
+	if broker == nil {
+		response.NotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	ch, unsubscribe := broker.Subscribe(userID)
+	defer unsubscribe()
+
+	stream, err := response.NewEventStream(w)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	err = events.StreamCoalesced(ctx, stream, ch, unreadCounterDelay,
+		func(e *model.Event) bool { return e.Type != model.EventEntriesStarred },
+		func() error {
+			return stream.Send("unread", map[string]int{
+				"unread": h.store.CountUnreadEntries(ctx, userID),
+			})
+		})
+	if err != nil {
+		logging.FromContext(ctx).Debug("ui: event stream closed",
+			slog.Any("error", err))
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+// bulkUpdateFeeds applies the action of the feeds page to the selected feeds:
+// move them to another category, disable or enable them.
+func (h *handler) bulkUpdateFeeds(w http.ResponseWriter, r *http.Request) {
+	feedIDs, err := formFeedIDs(r)
+	if err != nil {
+		response.BadRequest(w, r, err)
+		return
+	}
+
+	modify := model.FeedsModificationRequest{FeedIDs: feedIDs}
+	switch r.PostFormValue("action") {
+	case "move":
+		modify.Changes.CategoryID = new(request.FormInt64Value(r, "category_id"))
+	case "disable":
+		modify.Changes.Disabled = new(true)
+	case "enable":
+		modify.Changes.Disabled = new(false)
+	default:
+		response.BadRequest(w, r, errors.New("invalid bulk action"))
+		return
+	}
+
+	ctx := r.Context()
+	userID := request.UserID(r)
+	lerr := validator.ValidateFeedsModification(ctx, h.store, userID, &modify)
+	if lerr != nil {
+		response.BadRequest(w, r, lerr.Error())
+		return
+	}
+
+	feeds, err := h.store.UpdateFeeds(ctx, userID, &modify)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if feeds == nil {
+		response.NotFound(w, r)
+		return
+	}
+	h.redirect(w, r, "feeds")
+}
+
+// bulkRemoveFeeds removes the selected feeds of the feeds page.
+func (h *handler) bulkRemoveFeeds(w http.ResponseWriter, r *http.Request) {
+	feedIDs, err := formFeedIDs(r)
+	if err != nil {
+		response.BadRequest(w, r, err)
+		return
+	} else if len(feedIDs) == 0 {
+		response.BadRequest(w, r, errors.New("no feeds selected"))
+		return
+	}
+
+	err = h.store.RemoveMultipleFeeds(r.Context(), request.UserID(r), feedIDs)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+	h.redirect(w, r, "feeds")
+}
+
+// formFeedIDs returns IDs of feeds selected by the feed_id checkboxes.
+func formFeedIDs(r *http.Request) ([]int64, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	feedIDs := make([]int64, 0, len(r.PostForm["feed_id"]))
+	for _, s := range r.PostForm["feed_id"] {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		feedIDs = append(feedIDs, id)
+	}
+	return feedIDs, nil
+}
@@ -30,6 +30,12 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		return err
 	})
 
+	var hits []model.FilterRuleHit
+	v.Go(func(ctx context.Context) (err error) {
+		hits, err = h.store.FeedFilterRuleHits(ctx, v.UserID(), feedID)
+		return err
+	})
+
 	if err := v.Wait(); err != nil {
 		response.ServerError(w, r, err)
 		return
@@ -52,6 +58,7 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		KeepFilterEntryRules:        feed.KeepFilterEntryRules(),
 		Crawler:                     feed.Crawler,
 		IgnoreEntryUpdates:          feed.IgnoreEntryUpdates(),
+		RenderPages:                 feed.RenderPages(),
 		UserAgent:                   feed.UserAgent,
 		Cookie:                      feed.Cookie,
 		CategoryID:                  feed.Category.ID,
@@ -73,6 +80,8 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		PushoverEnabled:             feed.PushoverEnabled,
 		PushoverPriority:            feed.PushoverPriority,
 		ProxyURL:                    feed.ProxyURL,
+		RefreshInterval:             feed.RefreshInterval(),
+		Retention:                   feed.Extra.Retention,
 	}
 
 	v.Set("menu", "feeds").
@@ -80,6 +89,11 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		Set("categories", categories).
 		Set("feed", feed).
 		Set("defaultUserAgent", config.HTTPClientUserAgent()).
-		Set("hasProxyConfigured", config.HasHTTPClientProxyURLConfigured())
+		Set("hasProxyConfigured", config.HasHTTPClientProxyURLConfigured()).
+		Set("hasRenderService", config.RenderServiceURL() != nil).
+		Set("blockRuleHits", ruleHits(feed.BlockFilterEntryRules(),
+			model.FilterActionBlock, hits)).
+		Set("keepRuleHits", ruleHits(feed.KeepFilterEntryRules(),
+			model.FilterActionKeep, hits))
 	response.HTML(w, r, v.Render("edit_feed"))
 }
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) previewFeedFilter(w http.ResponseWriter, r *http.Request) {
+	f := form.NewFeedForm(r)
+	previewRequest := model.FilterPreviewRequest{
+		BlockAuthors:          &f.BlockAuthors,
+		BlockFilterEntryRules: &f.BlockFilterEntryRules,
+		KeepFilterEntryRules:  &f.KeepFilterEntryRules,
+	}
+
+	lerr := validator.ValidateFilterPreview(&previewRequest)
+	if lerr != nil {
+		h.showUpdateFeedError(w, r, func(v *View) {
+			v.Set("form", f).
+				Set("errorMessage", lerr.Translate(v.User().Language))
+			response.HTML(w, r, v.Render("edit_feed"))
+		})
+		return
+	}
+
+	ctx := r.Context()
+	user := request.User(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	feed, err := h.store.FeedByID(ctx, user.ID, feedID)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if feed == nil {
+		response.NotFound(w, r)
+		return
+	}
+
+	previewRequest.Patch(feed)
+	preview, err := feedHandler.PreviewFilter(ctx, h.store, user, feed)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	h.showUpdateFeedError(w, r, func(v *View) {
+		v.Set("form", f).Set("filterPreview", preview)
+		response.HTML(w, r, v.Render("edit_feed"))
+	})
+}
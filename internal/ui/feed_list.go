@@ -9,6 +9,7 @@ import (
 
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
 )
 
 func (h *handler) showFeedsPage(w http.ResponseWriter, r *http.Request) {
@@ -20,6 +21,19 @@ func (h *handler) showFeedsPage(w http.ResponseWriter, r *http.Request) {
 		return err
 	})
 
+	var categories []model.Category
+	v.Go(func(ctx context.Context) (err error) {
+		categories, err = h.store.Categories(ctx, v.UserID())
+		return err
+	})
+
+	var searches []model.SavedSearch
+	v.Go(func(ctx context.Context) (err error) {
+		searches, err = h.store.SavedSearchesWithCounters(ctx, v.UserID(),
+			timezone.Location(v.User().Timezone))
+		return err
+	})
+
 	if err := v.Wait(); err != nil {
 		response.ServerError(w, r, err)
 		return
@@ -27,6 +41,8 @@ func (h *handler) showFeedsPage(w http.ResponseWriter, r *http.Request) {
 
 	v.Set("menu", "feeds").
 		Set("feeds", feeds).
+		Set("categories", categories).
+		Set("savedSearches", searches).
 		Set("total", len(feeds))
 	response.HTML(w, r, v.Render("feeds"))
 }
@@ -29,6 +29,8 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		KeepFilterEntryRules:  model.OptionalString(f.KeepFilterEntryRules),
 		ProxyURL:              model.OptionalString(f.ProxyURL),
 		CommentsURLTemplate:   model.OptionalString(f.CommentsURLTemplate),
+		RefreshInterval:       &f.RefreshInterval,
+		Retention:             &f.Retention,
 	}
 
 	ctx := r.Context()
@@ -89,6 +91,7 @@ func (h *handler) showUpdateFeedError(w http.ResponseWriter, r *http.Request,
 	v.Set("menu", "feeds").
 		Set("categories", categories).
 		Set("feed", feed).
-		Set("defaultUserAgent", config.HTTPClientUserAgent())
+		Set("defaultUserAgent", config.HTTPClientUserAgent()).
+		Set("hasRenderService", config.RenderServiceURL() != nil)
 	renderFunc(v)
 }
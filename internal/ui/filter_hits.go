@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"slices"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// userRuleHits sums up hits of every feed by rule. Hits are counted by the
+// source line of rules, so hits of a feed, which has the same line in its own
+// or its category rules, aren't hits of the user rule and are skipped.
+func userRuleHits(hits []model.FilterRuleHit, feeds model.Feeds,
+) []model.FilterRuleHit {
+	byID := make(map[int64]*model.Feed, len(feeds))
+	for _, f := range feeds {
+		byID[f.ID] = f
+	}
+
+	byRule := make(map[model.FilterRuleKey]int)
+	var result []model.FilterRuleHit
+	for _, h := range hits {
+		if f := byID[h.FeedID]; f != nil && feedHasRule(f, h.Action, h.Rule) {
+			continue
+		}
+
+		k := model.FilterRuleKey{Action: h.Action, Rule: h.Rule}
+		i, ok := byRule[k]
+		if !ok {
+			byRule[k] = len(result)
+			result = append(result, model.FilterRuleHit{
+				Action: h.Action, Rule: h.Rule,
+			})
+			i = len(result) - 1
+		}
+		result[i].Hits += h.Hits
+		if h.LastHitAt.After(result[i].LastHitAt) {
+			result[i].LastHitAt = h.LastHitAt
+		}
+	}
+	return result
+}
+
+func feedHasRule(f *model.Feed, action model.FilterAction, rule string) bool {
+	rules := f.KeepFilterEntryRules()
+	if action == model.FilterActionBlock {
+		rules = f.BlockFilterEntryRules()
+		if f.Category != nil {
+			rules += "\n" + f.Category.BlockFilter()
+		}
+	}
+	return slices.Contains(ruleLines(rules), rule)
+}
+
+func ruleLines(rules string) []string {
+	var lines []string
+	for line := range strings.SplitSeq(rules, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ruleHits returns hits of every line of rules, in the same order. Rules
+// without hits are returned too, with zero hits.
+func ruleHits(rules string, action model.FilterAction,
+	hits []model.FilterRuleHit,
+) []model.FilterRuleHit {
+	byRule := make(map[string]model.FilterRuleHit, len(hits))
+	for _, h := range hits {
+		if h.Action == action {
+			byRule[h.Rule] = h
+		}
+	}
+
+	var result []model.FilterRuleHit
+	for _, line := range ruleLines(rules) {
+		h, ok := byRule[line]
+		if !ok {
+			h = model.FilterRuleHit{Action: action, Rule: line}
+		}
+		result = append(result, h)
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) applyFeedFilters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := request.User(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	feed, err := h.store.FeedByID(ctx, user.ID, feedID)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if feed == nil {
+		response.NotFound(w, r)
+		return
+	}
+
+	h.applyFilters(w, r, feed)
+	h.redirect(w, r, "editFeed", "feedID", feedID)
+}
+
+func (h *handler) applyCategoryFilters(w http.ResponseWriter, r *http.Request,
+) {
+	ctx := r.Context()
+	user := request.User(r)
+	categoryID := request.RouteInt64Param(r, "categoryID")
+
+	category, err := h.store.Category(ctx, user.ID, categoryID)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if category == nil {
+		response.NotFound(w, r)
+		return
+	}
+
+	feeds, err := h.store.FeedsByCategory(ctx, user.ID, categoryID)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	h.applyFilters(w, r, feeds...)
+	h.redirect(w, r, "editCategory", "categoryID", categoryID)
+}
+
+func (h *handler) applyAllFilters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := request.User(r)
+
+	feeds, err := h.store.Feeds(ctx, user.ID)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	h.applyFilters(w, r, feeds...)
+	h.redirect(w, r, "settings")
+}
+
+func (h *handler) applyFilters(w http.ResponseWriter, r *http.Request,
+	feeds ...*model.Feed,
+) {
+	ctx := r.Context()
+	sess := session.FromContext(ctx)
+
+	changed, err := filter.FilterStoredEntries(ctx, h.store, request.User(r),
+		feeds...)
+	if err != nil {
+		sess.NewFlashErrorMessage(err.Error())
+		return
+	}
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess.NewFlashMessage(printer.Plural("alert.filters_applied", changed,
+		changed))
+}
@@ -5,6 +5,8 @@ package form // import "miniflux.app/v2/internal/ui/form"
 
 import (
 	"net/http"
+
+	"miniflux.app/v2/internal/model"
 )
 
 // CategoryForm represents a feed form in the UI
@@ -13,6 +15,7 @@ type CategoryForm struct {
 	HideGlobally bool
 	HideLabel    bool
 	BlockFilter  string
+	Retention    model.Retention
 }
 
 // NewCategoryForm returns a new CategoryForm.
@@ -22,5 +25,6 @@ func NewCategoryForm(r *http.Request) *CategoryForm {
 		HideGlobally: r.FormValue("hide_globally") == "1",
 		HideLabel:    r.FormValue("hideLabel") == "1",
 		BlockFilter:  r.FormValue("blockFilter"),
+		Retention:    newRetention(r),
 	}
 }
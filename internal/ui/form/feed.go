@@ -26,6 +26,7 @@ type FeedForm struct {
 	KeepFilterEntryRules        string
 	Crawler                     bool
 	IgnoreEntryUpdates          bool
+	RenderPages                 bool
 	UserAgent                   string
 	Cookie                      string
 	CategoryID                  int64
@@ -47,6 +48,8 @@ type FeedForm struct {
 	PushoverEnabled             bool
 	PushoverPriority            int
 	ProxyURL                    string
+	RefreshInterval             int
+	Retention                   model.Retention
 }
 
 func (self *FeedForm) BlockAuthorsFrom(s string) {
@@ -93,6 +96,7 @@ func (self *FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.Extra.KeepFilterEntryRules = self.KeepFilterEntryRules
 	feed.Crawler = self.Crawler
 	feed.WithIgnoreEntryUpdates(self.IgnoreEntryUpdates)
+	feed.WithRenderPages(self.RenderPages)
 	feed.UserAgent = self.UserAgent
 	feed.Cookie = self.Cookie
 	feed.ParsingErrorCount = 0
@@ -115,6 +119,8 @@ func (self *FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.PushoverPriority = self.PushoverPriority
 	feed.ProxyURL = self.ProxyURL
 	feed.WithCommentsURLTemplate(self.CommentsURLTemplate)
+	feed.WithRefreshInterval(self.RefreshInterval)
+	feed.Extra.Retention = self.Retention
 	return feed
 }
 
@@ -135,6 +141,11 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		pushoverPriority = 0
 	}
 
+	refreshInterval, err := strconv.Atoi(r.FormValue("refresh_interval"))
+	if err != nil {
+		refreshInterval = 0
+	}
+
 	ff := &FeedForm{
 		FeedURL:                     r.FormValue("feed_url"),
 		SiteURL:                     r.FormValue("site_url"),
@@ -150,6 +161,7 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		KeepFilterEntryRules:        r.FormValue("keep_filter_entry_rules"),
 		Crawler:                     r.FormValue("crawler") == "1",
 		IgnoreEntryUpdates:          r.FormValue("ignore_entry_updates") == "1",
+		RenderPages:                 r.FormValue("render_pages") == "1",
 		CategoryID:                  int64(categoryID),
 		Username:                    r.FormValue("feed_username"),
 		Password:                    r.FormValue("feed_password"),
@@ -168,6 +180,8 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		PushoverEnabled:             r.FormValue("pushover_enabled") == "1",
 		PushoverPriority:            pushoverPriority,
 		ProxyURL:                    r.FormValue("proxy_url"),
+		RefreshInterval:             refreshInterval,
+		Retention:                   newRetention(r),
 	}
 	ff.BlockAuthorsFrom(r.FormValue("blockAuthors"))
 	return ff
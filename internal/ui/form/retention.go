@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// newRetention parses the retention policy fields of feed and category forms.
+func newRetention(r *http.Request) model.Retention {
+	days, _ := strconv.Atoi(r.FormValue("retention_days"))
+	keep, _ := strconv.Atoi(r.FormValue("retention_keep"))
+	return model.Retention{
+		Never: r.FormValue("retention_never") == "1",
+		Days:  days,
+		Keep:  keep,
+	}
+}
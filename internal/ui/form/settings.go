@@ -53,6 +53,7 @@ type SettingsForm struct {
 	MediaPlaybackRate       float64
 	BlockFilterEntryRules   string
 	KeepFilterEntryRules    string
+	TagEntryRules           string
 	AlwaysOpenExternalLinks bool
 	OpenExternalLinkSameTab bool
 }
@@ -117,6 +118,7 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.MediaPlaybackRate = s.MediaPlaybackRate
 	user.BlockFilterEntryRules = s.BlockFilterEntryRules
 	user.KeepFilterEntryRules = s.KeepFilterEntryRules
+	user.Extra.TagEntryRules = s.TagEntryRules
 
 	MarkReadOnView, MarkReadOnMediaPlayerCompletion := ExtractMarkAsReadBehavior(s.MarkReadBehavior)
 	user.MarkReadOnView = MarkReadOnView
@@ -210,6 +212,7 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		MediaPlaybackRate:       mediaPlaybackRate,
 		BlockFilterEntryRules:   r.FormValue("block_filter_entry_rules"),
 		KeepFilterEntryRules:    r.FormValue("keep_filter_entry_rules"),
+		TagEntryRules:           r.FormValue("tag_entry_rules"),
 		AlwaysOpenExternalLinks: r.FormValue("always_open_external_links") != "",
 		OpenExternalLinkSameTab: r.FormValue("open_external_links_in_new_tab") == "",
 	}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// SiteRuleForm represents the site rule form.
+type SiteRuleForm struct {
+	Hostname     string
+	ScraperRules string
+	RewriteRules string
+	Referer      string
+	UserAgent    string
+	Global       bool
+}
+
+// NewSiteRuleForm returns a new SiteRuleForm.
+func NewSiteRuleForm(r *http.Request) *SiteRuleForm {
+	return &SiteRuleForm{
+		Hostname:     r.FormValue("hostname"),
+		ScraperRules: r.FormValue("scraper_rules"),
+		RewriteRules: r.FormValue("rewrite_rules"),
+		Referer:      r.FormValue("referer"),
+		UserAgent:    r.FormValue("user_agent"),
+		Global:       r.FormValue("global") == "1",
+	}
+}
+
+// SiteRuleFormFrom returns the form of an existing site rule.
+func SiteRuleFormFrom(rule *model.SiteRule) *SiteRuleForm {
+	return &SiteRuleForm{
+		Hostname:     rule.Hostname,
+		ScraperRules: rule.ScraperRules,
+		RewriteRules: rule.RewriteRules,
+		Referer:      rule.Referer,
+		UserAgent:    rule.UserAgent,
+		Global:       rule.Global(),
+	}
+}
+
+// Request returns the request to create or update the site rule.
+func (self *SiteRuleForm) Request() *model.SiteRuleRequest {
+	return &model.SiteRuleRequest{
+		Hostname:     self.Hostname,
+		ScraperRules: self.ScraperRules,
+		RewriteRules: self.RewriteRules,
+		Referer:      self.Referer,
+		UserAgent:    self.UserAgent,
+		Global:       self.Global,
+	}
+}
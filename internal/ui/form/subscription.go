@@ -9,8 +9,9 @@ import (
 
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/scraper"
 	"miniflux.app/v2/internal/urllib"
-	"miniflux.app/v2/internal/validator"
 )
 
 // SubscriptionForm represents the subscription form.
@@ -44,8 +45,16 @@ func (s *SubscriptionForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.invalid_feed_url")
 	}
 
-	if !validator.IsValidRegex(s.UrlRewriteRules) {
-		return locale.NewLocalizedError("error.feed_invalid_urlrewrite_rule")
+	if s.ScraperRules != "" {
+		if err := scraper.ValidateRules(s.ScraperRules); err != nil {
+			return locale.NewLocalizedError(
+				"Invalid scraper rules: " + err.Error())
+		}
+	}
+
+	if err := rewrite.ValidateURLRewriteRules(s.UrlRewriteRules); err != nil {
+		return locale.NewLocalizedError(
+			"Invalid URL rewrite rules: " + err.Error())
 	}
 
 	if s.ProxyURL != "" && !urllib.IsValidProxyURL(s.ProxyURL) {
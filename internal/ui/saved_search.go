@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) saveSavedSearch(w http.ResponseWriter, r *http.Request) {
+	searchRequest := model.SavedSearchRequest{
+		Title: r.FormValue("title"),
+		Query: r.FormValue("q"),
+	}
+
+	userID := request.UserID(r)
+	lerr := validator.ValidateSavedSearch(r.Context(), h.store, userID, 0,
+		&searchRequest)
+	if lerr == nil {
+		search, err := h.store.CreateSavedSearch(r.Context(), userID,
+			&searchRequest)
+		if err != nil {
+			response.ServerError(w, r, err)
+			return
+		}
+		h.redirect(w, r, "savedSearchEntries", "savedSearchID", search.ID)
+		return
+	}
+
+	v := h.View(r)
+	if err := v.Wait(); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v.Set("menu", "search").
+		Set("searchQuery", searchRequest.Query).
+		Set("savedSearchTitle", searchRequest.Title).
+		Set("errorMessage", lerr.Translate(v.User().Language))
+	response.HTML(w, r, v.Render("search"))
+}
+
+func (h *handler) markSavedSearchAsRead(w http.ResponseWriter,
+	r *http.Request,
+) {
+	user := request.User(r)
+	id := request.RouteInt64Param(r, "savedSearchID")
+
+	search, err := h.store.SavedSearchByID(r.Context(), user.ID, id)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if search == nil {
+		response.NotFound(w, r)
+		return
+	}
+
+	q, err := search.SearchQuery(timezone.Location(user.Timezone))
+	if err != nil {
+		response.BadRequest(w, r, err)
+		return
+	}
+
+	err = h.store.MarkSavedSearchAsRead(r.Context(), user.ID, q, time.Now())
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+	h.redirect(w, r, "feeds")
+}
+
+func (h *handler) removeSavedSearch(w http.ResponseWriter, r *http.Request) {
+	id := request.RouteInt64Param(r, "savedSearchID")
+	affected, err := h.store.RemoveSavedSearch(r.Context(), request.UserID(r),
+		id)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if !affected {
+		response.NotFound(w, r)
+		return
+	}
+	h.redirect(w, r, "feeds")
+}
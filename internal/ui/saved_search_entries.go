@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
+)
+
+func (h *handler) showSavedSearchEntriesPage(w http.ResponseWriter,
+	r *http.Request,
+) {
+	h.showSavedSearchEntries(w, r, true)
+}
+
+func (h *handler) showSavedSearchEntriesAllPage(w http.ResponseWriter,
+	r *http.Request,
+) {
+	h.showSavedSearchEntries(w, r, false)
+}
+
+func (h *handler) showSavedSearchEntries(w http.ResponseWriter,
+	r *http.Request, unreadOnly bool,
+) {
+	v := h.View(r).WithSaveEntry()
+	user := v.User()
+
+	id := request.RouteInt64Param(r, "savedSearchID")
+	search, err := h.store.SavedSearchByID(r.Context(), user.ID, id)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	} else if search == nil {
+		response.NotFound(w, r)
+		return
+	}
+
+	q, err := search.SearchQuery(timezone.Location(user.Timezone))
+	if err != nil {
+		response.BadRequest(w, r, err)
+		return
+	}
+
+	offset := request.QueryIntParam(r, "offset", 0)
+	query := h.store.NewEntryQueryBuilder(user.ID).
+		WithSearch(q).
+		WithSorting(user.EntryOrder, user.EntryDirection).
+		WithSorting("id", user.EntryDirection).
+		WithOffset(offset).
+		WithLimit(user.EntriesPerPage).
+		WithOffsetID(request.QueryInt64Param(r, "offsetID", 0))
+
+	routeName := "savedSearchEntriesAll"
+	if unreadOnly {
+		routeName = "savedSearchEntries"
+		query.WithStatus(model.EntryStatusUnread)
+	} else {
+		query.WithoutStatus(model.EntryStatusRemoved)
+	}
+
+	entries, count, err := v.WaitEntriesCount(query)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v.WithEntries(entries).
+		Set("menu", "feeds").
+		Set("savedSearch", search).
+		Set("total", count).
+		Set("pagination", getPagination(
+			route.Path(h.router, routeName, "savedSearchID", id),
+			count, offset, user.EntriesPerPage)).
+		Set("showOnlyUnreadEntries", unreadOnly)
+	response.HTML(w, r, v.Render("saved_search_entries"))
+}
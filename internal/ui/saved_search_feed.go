@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/mediaproxy"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
+)
+
+// savedSearchFeedSize is the number of entries in saved search feeds.
+const savedSearchFeedSize = 50
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Content   atomContent `xml:"content"`
+	Category  []atomTerm  `xml:"category"`
+	Source    atomSource  `xml:"source"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomTerm struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomSource struct {
+	Title string   `xml:"title"`
+	Link  atomLink `xml:"link"`
+}
+
+type jsonFeed struct {
+	Version string         `json:"version"`
+	Title   string         `json:"title"`
+	FeedURL string         `json:"feed_url"`
+	Items   []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+func (h *handler) showSavedSearchAtomFeed(w http.ResponseWriter,
+	r *http.Request,
+) {
+	search, entries, ok := h.savedSearchFeedEntries(w, r)
+	if !ok {
+		return
+	}
+
+	selfURL := config.RootURL() + route.Path(h.router, "savedSearchAtomFeed",
+		"token", search.Token)
+	feed := atomFeed{
+		ID:      selfURL,
+		Title:   search.Title,
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: selfURL, Rel: "self"},
+		Entries: make([]atomEntry, len(entries)),
+	}
+
+	for i, e := range entries {
+		entry := atomEntry{
+			ID:        "urn:miniflux:entry:" + strconv.FormatInt(e.ID, 10),
+			Title:     e.Title,
+			Link:      atomLink{Href: e.URL},
+			Published: e.Date.UTC().Format(time.RFC3339),
+			Updated:   e.ChangedAt.UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "html", Body: e.Content},
+			Source: atomSource{
+				Title: e.Feed.Title,
+				Link:  atomLink{Href: e.Feed.SiteURL},
+			},
+		}
+		if e.Author != "" {
+			entry.Author = &atomAuthor{Name: e.Author}
+		}
+		for _, tag := range e.Tags {
+			entry.Category = append(entry.Category, atomTerm{Term: tag})
+		}
+		feed.Entries[i] = entry
+	}
+
+	body, err := xml.MarshalIndent(&feed, "", "  ")
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", "application/atom+xml; charset=utf-8").
+		WithHeader("Cache-Control", "no-cache").
+		WithBodyAsString(xml.Header + string(body)).
+		Write()
+}
+
+func (h *handler) showSavedSearchJSONFeed(w http.ResponseWriter,
+	r *http.Request,
+) {
+	search, entries, ok := h.savedSearchFeedEntries(w, r)
+	if !ok {
+		return
+	}
+
+	feed := jsonFeed{
+		Version: "https://jsonfeed.org/version/1.1",
+		Title:   search.Title,
+		FeedURL: config.RootURL() + route.Path(h.router, "savedSearchJSONFeed",
+			"token", search.Token),
+		Items: make([]jsonFeedItem, len(entries)),
+	}
+
+	for i, e := range entries {
+		item := jsonFeedItem{
+			ID:            strconv.FormatInt(e.ID, 10),
+			URL:           e.URL,
+			Title:         e.Title,
+			ContentHTML:   e.Content,
+			DatePublished: e.Date.Format(time.RFC3339),
+			DateModified:  e.ChangedAt.Format(time.RFC3339),
+			Tags:          e.Tags,
+		}
+		if e.Author != "" {
+			item.Authors = []jsonFeedAuthor{{Name: e.Author}}
+		}
+		feed.Items[i] = item
+	}
+
+	body, err := json.Marshal(&feed)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", "application/feed+json; charset=utf-8").
+		WithHeader("Cache-Control", "no-cache").
+		WithBodyAsBytes(body).
+		Write()
+}
+
+// savedSearchFeedEntries returns the saved search with the token of the
+// request and its latest entries. It writes the error response and returns
+// false on failure.
+func (h *handler) savedSearchFeedEntries(w http.ResponseWriter,
+	r *http.Request,
+) (*model.SavedSearch, model.Entries, bool) {
+	ctx := r.Context()
+	search, err := h.store.SavedSearchByToken(ctx,
+		request.RouteStringParam(r, "token"))
+	if err != nil {
+		response.ServerError(w, r, err)
+		return nil, nil, false
+	} else if search == nil {
+		response.NotFound(w, r)
+		return nil, nil, false
+	}
+
+	user, err := h.store.UserByID(ctx, search.UserID)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return nil, nil, false
+	} else if user == nil {
+		response.NotFound(w, r)
+		return nil, nil, false
+	}
+
+	q, err := search.SearchQuery(timezone.Location(user.Timezone))
+	if err != nil {
+		response.BadRequest(w, r, err)
+		return nil, nil, false
+	}
+
+	entries, err := h.store.NewEntryQueryBuilder(user.ID).
+		WithSearch(q).
+		WithoutStatus(model.EntryStatusRemoved).
+		WithSorting("published_at", "desc").
+		WithSorting("id", "desc").
+		WithLimit(savedSearchFeedSize).
+		WithContent(true).
+		GetEntries(ctx)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return nil, nil, false
+	}
+
+	for _, e := range entries {
+		e.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router,
+			e.Content)
+	}
+	return search, entries, true
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"context"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/urllib"
+)
+
+func (h *handler) showScraperDiagnosticsPage(w http.ResponseWriter,
+	r *http.Request,
+) {
+	v := h.View(r)
+	if err := v.Wait(); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	pageURL := request.QueryStringParam(r, "url", "")
+	entryID := request.QueryInt64Param(r, "entry_id", 0)
+	v.Set("menu", "settings").
+		Set("url", pageURL).
+		Set("entryID", entryID)
+
+	switch {
+	case entryID > 0:
+		d, err := h.entryScraperDiagnostics(r.Context(), v.User(), entryID)
+		if err != nil {
+			response.ServerError(w, r, err)
+			return
+		}
+		v.Set("diagnostics", d)
+	case pageURL == "":
+	case !urllib.IsAbsoluteURL(pageURL):
+		lerr := locale.NewLocalizedError("error.scraper_diagnostics_invalid_url")
+		v.Set("errorMessage", lerr.Translate(v.User().Language))
+	default:
+		d, err := processor.DiagnoseScraper(r.Context(), h.store, &model.Feed{},
+			&model.Entry{URL: pageURL}, v.User())
+		if err != nil {
+			response.ServerError(w, r, err)
+			return
+		}
+		v.Set("diagnostics", d)
+	}
+	response.HTML(w, r, v.Render("scraper_diagnostics"))
+}
+
+func (h *handler) entryScraperDiagnostics(ctx context.Context,
+	user *model.User, entryID int64,
+) (*model.ScraperDiagnostics, error) {
+	entry, err := h.store.NewEntryQueryBuilder(user.ID).
+		WithEntryID(entryID).
+		WithoutStatus(model.EntryStatusRemoved).
+		GetEntry(ctx)
+	if err != nil {
+		return nil, err
+	} else if entry == nil {
+		return nil, response.ErrNotFound
+	}
+
+	feed, err := h.store.FeedByID(ctx, user.ID, entry.FeedID)
+	if err != nil {
+		return nil, err
+	} else if feed == nil {
+		return nil, response.ErrNotFound
+	}
+	return processor.DiagnoseScraper(ctx, h.store, feed, entry, user)
+}
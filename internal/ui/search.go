@@ -10,6 +10,7 @@ import (
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/timezone"
 )
 
 func (h *handler) showSearchPage(w http.ResponseWriter, r *http.Request) {
@@ -22,9 +23,17 @@ func (h *handler) showSearchPage(w http.ResponseWriter, r *http.Request) {
 	var entries model.Entries
 	var count int
 
-	if searchQuery != "" {
+	search, err := model.ParseSearchQuery(searchQuery,
+		timezone.Location(user.Timezone))
+	if err != nil {
+		v.Set("errorMessage", err.Error())
+	}
+
+	if searchQuery != "" && search != nil {
 		query := h.store.NewEntryQueryBuilder(v.UserID()).
-			WithSearchQuery(searchQuery).
+			WithSearch(search).
+			WithSorting(user.EntryOrder, user.EntryDirection).
+			WithSorting("id", user.EntryDirection).
 			WithOffset(offset).
 			WithLimit(user.EntriesPerPage)
 
@@ -36,7 +45,6 @@ func (h *handler) showSearchPage(w http.ResponseWriter, r *http.Request) {
 
 		query.WithOffsetID(request.QueryInt64Param(r, "offsetID", 0))
 
-		var err error
 		entries, count, err = v.WaitEntriesCount(query)
 		if err != nil {
 			response.ServerError(w, r, err)
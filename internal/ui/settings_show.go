@@ -28,6 +28,18 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		return err
 	})
 
+	var hits []model.FilterRuleHit
+	v.Go(func(ctx context.Context) (err error) {
+		hits, err = h.store.FilterRuleHits(ctx, v.UserID())
+		return err
+	})
+
+	var feeds model.Feeds
+	v.Go(func(ctx context.Context) (err error) {
+		feeds, err = h.store.Feeds(ctx, v.UserID())
+		return err
+	})
+
 	var webAuthnCount int
 	v.Go(func(ctx context.Context) error {
 		webAuthnCount = h.store.CountWebAuthnCredentialsByUserID(ctx, v.UserID())
@@ -40,6 +52,7 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user := v.User()
+	hits = userRuleHits(hits, feeds)
 	settingsForm := form.SettingsForm{
 		Username:               user.Username,
 		Theme:                  user.Theme,
@@ -65,6 +78,7 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		MediaPlaybackRate:       user.MediaPlaybackRate,
 		BlockFilterEntryRules:   user.BlockFilterEntryRules,
 		KeepFilterEntryRules:    user.KeepFilterEntryRules,
+		TagEntryRules:           user.TagEntryRules(),
 		AlwaysOpenExternalLinks: user.AlwaysOpenExternalLinks(),
 		OpenExternalLinkSameTab: user.OpenExternalLinkSameTab(),
 	}
@@ -83,6 +97,10 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		Set("default_home_pages", model.HomePages()).
 		Set("categories_sorting_options", model.CategoriesSortingOptions()).
 		Set("countWebAuthnCerts", webAuthnCount).
-		Set("webAuthnCerts", creds)
+		Set("webAuthnCerts", creds).
+		Set("blockRuleHits", ruleHits(user.BlockFilterEntryRules,
+			model.FilterActionBlock, hits)).
+		Set("keepRuleHits", ruleHits(user.KeepFilterEntryRules,
+			model.FilterActionKeep, hits))
 	response.HTML(w, r, v.Render("settings"))
 }
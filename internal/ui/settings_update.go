@@ -37,6 +37,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		MediaPlaybackRate:      model.OptionalNumber(f.MediaPlaybackRate),
 		BlockFilterEntryRules:  model.OptionalString(f.BlockFilterEntryRules),
 		KeepFilterEntryRules:   model.OptionalString(f.KeepFilterEntryRules),
+		TagEntryRules:          model.OptionalString(f.TagEntryRules),
 		ExternalFontHosts:      model.OptionalString(f.ExternalFontHosts),
 	}
 
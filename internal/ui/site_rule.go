@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/siterules"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) showSiteRulesPage(w http.ResponseWriter, r *http.Request) {
+	v := h.View(r)
+	if err := h.siteRulesView(v); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+	response.HTML(w, r, v.Render("site_rules"))
+}
+
+func (h *handler) siteRulesView(v *View) error {
+	var rules model.SiteRules
+	v.Go(func(ctx context.Context) (err error) {
+		rules, err = h.store.SiteRules(ctx, v.UserID())
+		return err
+	})
+
+	if err := v.Wait(); err != nil {
+		return err
+	}
+
+	v.Set("menu", "settings").
+		Set("siteRules", rules)
+	return nil
+}
+
+func (h *handler) showCreateSiteRulePage(w http.ResponseWriter,
+	r *http.Request,
+) {
+	v := h.View(r)
+	if err := v.Wait(); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	f := &form.SiteRuleForm{Hostname: r.URL.Query().Get("hostname")}
+	v.Set("menu", "settings").
+		Set("form", f)
+	response.HTML(w, r, v.Render("create_site_rule"))
+}
+
+func (h *handler) saveSiteRule(w http.ResponseWriter, r *http.Request) {
+	user := request.User(r)
+	f := form.NewSiteRuleForm(r)
+	if f.Global && !user.IsAdmin {
+		response.Forbidden(w, r)
+		return
+	}
+
+	ruleRequest := f.Request()
+	lerr := validator.ValidateSiteRule(r.Context(), h.store,
+		ruleRequest.UserID(user.ID), 0, ruleRequest)
+	if lerr == nil {
+		_, err := h.store.CreateSiteRule(r.Context(), user.ID, ruleRequest)
+		if err != nil {
+			response.ServerError(w, r, err)
+			return
+		}
+		h.redirect(w, r, "siteRules")
+		return
+	}
+
+	v := h.View(r)
+	if err := v.Wait(); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v.Set("menu", "settings").
+		Set("form", f).
+		Set("errorMessage", lerr.Translate(v.User().Language))
+	response.HTML(w, r, v.Render("create_site_rule"))
+}
+
+// siteRule returns the site rule from the route, which the user can modify:
+// own site rules or global site rules for admins.
+func (h *handler) siteRule(r *http.Request) (*model.SiteRule, error) {
+	user := request.User(r)
+	id := request.RouteInt64Param(r, "siteRuleID")
+	rule, err := h.store.SiteRuleByID(r.Context(), user.ID, id)
+	if err != nil {
+		return nil, err
+	} else if rule == nil {
+		return nil, response.ErrNotFound
+	} else if rule.Global() && !user.IsAdmin {
+		return nil, response.ErrForbidden
+	}
+	return rule, nil
+}
+
+func (h *handler) showEditSiteRulePage(w http.ResponseWriter,
+	r *http.Request,
+) {
+	rule, err := h.siteRule(r)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v := h.View(r)
+	if err := v.Wait(); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v.Set("menu", "settings").
+		Set("siteRule", rule).
+		Set("form", form.SiteRuleFormFrom(rule))
+	response.HTML(w, r, v.Render("edit_site_rule"))
+}
+
+func (h *handler) updateSiteRule(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.siteRule(r)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	f := form.NewSiteRuleForm(r)
+	f.Global = rule.Global()
+	ruleRequest := f.Request()
+	lerr := validator.ValidateSiteRule(r.Context(), h.store, rule.UserID,
+		rule.ID, ruleRequest)
+	if lerr == nil {
+		rule.Hostname = ruleRequest.Hostname
+		rule.ScraperRules = ruleRequest.ScraperRules
+		rule.RewriteRules = ruleRequest.RewriteRules
+		rule.Referer = ruleRequest.Referer
+		rule.UserAgent = ruleRequest.UserAgent
+		if _, err := h.store.UpdateSiteRule(r.Context(), rule); err != nil {
+			response.ServerError(w, r, err)
+			return
+		}
+		h.redirect(w, r, "siteRules")
+		return
+	}
+
+	v := h.View(r)
+	if err := v.Wait(); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v.Set("menu", "settings").
+		Set("siteRule", rule).
+		Set("form", f).
+		Set("errorMessage", lerr.Translate(v.User().Language))
+	response.HTML(w, r, v.Render("edit_site_rule"))
+}
+
+func (h *handler) removeSiteRule(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.siteRule(r)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	_, err = h.store.RemoveSiteRule(r.Context(), rule.UserID, rule.ID)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+	h.redirect(w, r, "siteRules")
+}
+
+func (h *handler) exportSiteRules(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	filename := "miniflux-site-rules.yaml"
+	if request.QueryBoolParam(r, "global", false) {
+		userID, filename = 0, "miniflux-global-site-rules.yaml"
+	}
+
+	var b bytes.Buffer
+	err := siterules.NewHandler(h.store).Export(r.Context(), userID, &b)
+	if err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", "application/yaml").
+		WithHeader("Content-Disposition",
+			`attachment; filename="`+filename+`"`).
+		WithBodyAsBytes(b.Bytes()).
+		Write()
+}
+
+func (h *handler) importSiteRules(w http.ResponseWriter, r *http.Request) {
+	user := request.User(r)
+	userID := user.ID
+	if r.FormValue("global") == "1" {
+		if !user.IsAdmin {
+			response.Forbidden(w, r)
+			return
+		}
+		userID = 0
+	}
+
+	file, _, err := r.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		h.redirect(w, r, "siteRules")
+		return
+	} else if err != nil {
+		response.BadRequest(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	_, err = siterules.NewHandler(h.store).Import(r.Context(), userID, file)
+	if err == nil {
+		h.redirect(w, r, "siteRules")
+		return
+	} else if !errors.Is(err, siterules.ErrInvalidDocument) {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v := h.View(r)
+	if err := h.siteRulesView(v); err != nil {
+		response.ServerError(w, r, err)
+		return
+	}
+
+	v.Set("errorMessage", err.Error())
+	response.HTML(w, r, v.Render("site_rules"))
+}
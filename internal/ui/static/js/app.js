@@ -910,6 +910,28 @@ function updateUnreadCounterValue(delta) {
     }
 }
 
+/**
+ * Keep the unread counter of the main menu up to date with the server.
+ */
+function initializeEventStream() {
+    const eventsURL = document.body.dataset.eventsUrl;
+    if (!eventsURL || typeof EventSource === "undefined") {
+        return;
+    }
+
+    const eventSource = new EventSource(eventsURL);
+    eventSource.addEventListener("unread", (event) => {
+        const { unread } = JSON.parse(event.data);
+        document.querySelectorAll('a[data-page="unread"] span.unread-counter').forEach((element) => {
+            element.textContent = unread;
+        });
+
+        if (window.location.href.endsWith('/unread')) {
+            document.title = document.title.replace(/\(\d+\)/, `(${unread})`);
+        }
+    });
+}
+
 /**
  * Handle confirmation messages for actions that require user confirmation.
  *
@@ -1264,6 +1286,7 @@ initializeKeyboardShortcuts();
 initializeTouchHandler();
 initializeClickHandlers();
 initializePWA();
+initializeEventStream();
 
 // Reload the page if it was restored from the back-forward cache and mark entries as read is enabled.
 window.addEventListener("pageshow", (event) => {
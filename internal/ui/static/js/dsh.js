@@ -44,8 +44,17 @@ async function markItemsRead(items) {
 
 function initDataConfirm() {
   document.body.addEventListener("click", (event) => {
-    if (event.target.closest(":is(a, button)[data-confirm]"))
-      handleConfirmationMessage(event.target, messageConfirmed);
+    const el = event.target.closest(":is(a, button)[data-confirm]");
+    if (!el) return;
+
+    // Submit buttons submit their form with selected items, like feeds of the
+    // bulk form, once confirmed.
+    if (el.type === "submit" && el.form) {
+      event.preventDefault();
+      handleConfirmationMessage(el, () => el.form.requestSubmit(el));
+      return;
+    }
+    handleConfirmationMessage(event.target, messageConfirmed);
   }, true);
 }
 
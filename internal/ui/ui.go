@@ -74,6 +74,12 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 
 		m.NameHandleFunc("/proxy/{encodedDigest}/{encodedURL}", mediaproxy.Serve,
 			"proxy")
+
+		// Saved search feeds.
+		m.NameHandleFunc("GET /search/{token}/feed.atom",
+			h.showSavedSearchAtomFeed, "savedSearchAtomFeed")
+		m.NameHandleFunc("GET /search/{token}/feed.json",
+			h.showSavedSearchJSONFeed, "savedSearchJSONFeed")
 	})
 
 	m = m.Group().Use(hmw.WithUserSession(store))
@@ -122,6 +128,8 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 		m.NameHandleFunc("/feed/{feedID}/entry/{entryID}/download", h.downloadEntry,
 			"downloadEntry")
 
+		m.NameHandleFunc("GET /events", h.streamEvents, "streamEvents")
+
 		if config.WebAuthn() {
 			// WebAuthn flow
 			m.NameHandleFunc("/webauthn/deleteall",
@@ -146,6 +154,10 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 	// Unread page.
 	m.NameHandleFunc("/unread", h.showUnreadPage, "unread")
 
+	// Entry labels.
+	m.NameHandleFunc("POST /entry/{entryID}/labels", h.updateEntryLabels,
+		"updateEntryLabels")
+
 	// History pages.
 	m.NameHandleFunc("/history", h.showHistoryPage, "history")
 
@@ -155,15 +167,34 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 	// Search pages.
 	m.NameHandleFunc("/search", h.showSearchPage, "search")
 
+	// Saved search pages.
+	m.NameHandleFunc("POST /saved-searches", h.saveSavedSearch,
+		"saveSavedSearch")
+	m.NameHandleFunc("GET /saved-search/{savedSearchID}/entries",
+		h.showSavedSearchEntriesPage, "savedSearchEntries")
+	m.NameHandleFunc("GET /saved-search/{savedSearchID}/entries/all",
+		h.showSavedSearchEntriesAllPage, "savedSearchEntriesAll")
+	m.NameHandleFunc("POST /saved-search/{savedSearchID}/mark-all-as-read",
+		h.markSavedSearchAsRead, "markSavedSearchAsRead")
+	m.NameHandleFunc("POST /saved-search/{savedSearchID}/remove",
+		h.removeSavedSearch, "removeSavedSearch")
+
 	// Feed listing pages.
 	m.NameHandleFunc("/feeds", h.showFeedsPage, "feeds")
 	m.NameHandleFunc("POST /feeds/refresh", h.refreshAllFeeds, "refreshAllFeeds")
+	m.NameHandleFunc("POST /feeds/bulk", h.bulkUpdateFeeds, "bulkUpdateFeeds")
+	m.NameHandleFunc("POST /feeds/bulk/remove", h.bulkRemoveFeeds,
+		"bulkRemoveFeeds")
 
 	// Individual feed pages.
 	m.NameHandleFunc("POST /feed/{feedID}/refresh", h.refreshFeed, "refreshFeed")
 	m.NameHandleFunc("/feed/{feedID}/edit", h.showEditFeedPage, "editFeed")
 	m.NameHandleFunc("/feed/{feedID}/remove", h.removeFeed, "removeFeed")
 	m.NameHandleFunc("/feed/{feedID}/update", h.updateFeed, "updateFeed")
+	m.NameHandleFunc("POST /feed/{feedID}/filter-preview", h.previewFeedFilter,
+		"previewFeedFilter")
+	m.NameHandleFunc("POST /feed/{feedID}/apply-filters", h.applyFeedFilters,
+		"applyFeedFilters")
 	m.NameHandleFunc("/feed/{feedID}/entries", h.showFeedEntriesPage,
 		"feedEntries")
 	m.NameHandleFunc("/feed/{feedID}/entries/all", h.showFeedEntriesAllPage,
@@ -210,6 +241,8 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 		"removeCategory")
 	m.NameHandleFunc("POST /category/{categoryID}/mark-all-as-read",
 		h.markCategoryAsRead, "markCategoryAsRead")
+	m.NameHandleFunc("POST /category/{categoryID}/apply-filters",
+		h.applyCategoryFilters, "applyCategoryFilters")
 
 	// User pages.
 	m.NameHandleFunc("/users", h.showUsersPage, "users")
@@ -224,11 +257,33 @@ func Serve(m *mux.ServeMux, store *storage.Storage, pool *worker.Pool,
 	// Settings pages.
 	m.NameHandleFunc("GET /settings", h.showSettingsPage, "settings")
 	m.NameHandleFunc("POST /settings", h.updateSettings, "updateSettings")
+	m.NameHandleFunc("POST /settings/apply-filters", h.applyAllFilters,
+		"applyAllFilters")
 	m.NameHandleFunc("GET /integrations", h.showIntegrationPage, "integrations")
 	m.NameHandleFunc("POST /integration", h.updateIntegration,
 		"updateIntegration")
 	m.NameHandleFunc("/about", h.showAboutPage, "about")
 
+	// Site rules pages.
+	m.NameHandleFunc("GET /site-rules", h.showSiteRulesPage, "siteRules")
+	m.NameHandleFunc("GET /site-rules/create", h.showCreateSiteRulePage,
+		"createSiteRule")
+	m.NameHandleFunc("POST /site-rules", h.saveSiteRule, "saveSiteRule")
+	m.NameHandleFunc("GET /site-rules/export", h.exportSiteRules,
+		"exportSiteRules")
+	m.NameHandleFunc("POST /site-rules/import", h.importSiteRules,
+		"importSiteRules")
+	m.NameHandleFunc("GET /site-rule/{siteRuleID}/edit", h.showEditSiteRulePage,
+		"editSiteRule")
+	m.NameHandleFunc("POST /site-rule/{siteRuleID}/update", h.updateSiteRule,
+		"updateSiteRule")
+	m.NameHandleFunc("POST /site-rule/{siteRuleID}/remove", h.removeSiteRule,
+		"removeSiteRule")
+
+	// Scraper pages.
+	m.NameHandleFunc("GET /scraper/diagnostics", h.showScraperDiagnosticsPage,
+		"scraperDiagnostics")
+
 	// Session pages.
 	m.NameHandleFunc("/sessions", h.showSessionsPage, "sessions")
 	m.NameHandleFunc("/sessions/{sessionID}/remove", h.removeSession,
@@ -47,5 +47,12 @@ func ValidateCategoryModification(ctx context.Context, store *storage.Storage,
 				"The block list rule is invalid: " + err.Error())
 		}
 	}
+
+	if r.Retention != nil {
+		if err := r.Retention.Validate(); err != nil {
+			return locale.NewLocalizedError(
+				"Invalid retention policy: " + err.Error())
+		}
+	}
 	return nil
 }
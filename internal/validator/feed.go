@@ -11,6 +11,8 @@ import (
 	"miniflux.app/v2/internal/logging"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/scraper"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/urllib"
 )
@@ -53,20 +55,36 @@ func ValidateFeedCreation(ctx context.Context, store *storage.Storage,
 		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
 	}
 
-	if r.BlockFilterEntryRules != "" {
-		if _, err := filter.New(r.BlockFilterEntryRules); err != nil {
-			return locale.NewLocalizedError(
-				"The block list rule is invalid: " + err.Error())
-		}
+	if r.RefreshInterval < 0 {
+		return locale.NewLocalizedError(
+			"The refresh interval must be a positive number of minutes")
 	}
 
-	if r.KeepFilterEntryRules != "" {
-		if _, err := filter.New(r.KeepFilterEntryRules); err != nil {
-			return locale.NewLocalizedError(
-				"The keep list rule is invalid: " + err.Error())
-		}
+	if lerr := validateScraperRules(r.ScraperRules); lerr != nil {
+		return lerr
 	}
-	return nil
+
+	if lerr := validateURLRewriteRules(r.UrlRewriteRules); lerr != nil {
+		return lerr
+	}
+	return validateFilterRules(r.BlockFilterEntryRules, r.KeepFilterEntryRules)
+}
+
+// ValidateFeedsModification validates the modification of several feeds.
+// Changes of URLs and titles are specific to a feed and rejected.
+func ValidateFeedsModification(ctx context.Context, store *storage.Storage,
+	userID int64, r *model.FeedsModificationRequest,
+) *locale.LocalizedError {
+	if len(r.FeedIDs) == 0 {
+		return locale.NewLocalizedError("The list of feeds is empty")
+	}
+
+	if r.Changes.FeedURL != nil || r.Changes.SiteURL != nil ||
+		r.Changes.Title != nil {
+		return locale.NewLocalizedError(
+			"The feed URL, site URL and title can't be changed for several feeds")
+	}
+	return ValidateFeedModification(ctx, store, userID, 0, &r.Changes)
 }
 
 // ValidateFeedModification validates feed modification.
@@ -132,18 +150,71 @@ func ValidateFeedModification(ctx context.Context, store *storage.Storage,
 		}
 	}
 
-	if s := model.OptionalValue(r.BlockFilterEntryRules); s != "" {
-		if _, err := filter.New(s); err != nil {
+	if model.OptionalValue(r.RefreshInterval) < 0 {
+		return locale.NewLocalizedError(
+			"The refresh interval must be a positive number of minutes")
+	}
+
+	if r.ScraperRules != nil {
+		if lerr := validateScraperRules(*r.ScraperRules); lerr != nil {
+			return lerr
+		}
+	}
+
+	if r.UrlRewriteRules != nil {
+		if lerr := validateURLRewriteRules(*r.UrlRewriteRules); lerr != nil {
+			return lerr
+		}
+	}
+
+	if r.Retention != nil {
+		if err := r.Retention.Validate(); err != nil {
+			return locale.NewLocalizedError(
+				"Invalid retention policy: " + err.Error())
+		}
+	}
+
+	return validateFilterRules(model.OptionalValue(r.BlockFilterEntryRules),
+		model.OptionalValue(r.KeepFilterEntryRules))
+}
+
+// ValidateFilterPreview validates candidate filter rules of a feed.
+func ValidateFilterPreview(r *model.FilterPreviewRequest,
+) *locale.LocalizedError {
+	return validateFilterRules(model.OptionalValue(r.BlockFilterEntryRules),
+		model.OptionalValue(r.KeepFilterEntryRules))
+}
+
+func validateFilterRules(block, keep string) *locale.LocalizedError {
+	if block != "" {
+		if _, err := filter.New(block); err != nil {
 			return locale.NewLocalizedError(
 				"The block list rule is invalid: " + err.Error())
 		}
 	}
 
-	if s := model.OptionalValue(r.KeepFilterEntryRules); s != "" {
-		if _, err := filter.New(s); err != nil {
+	if keep != "" {
+		if _, err := filter.New(keep); err != nil {
 			return locale.NewLocalizedError(
 				"The keep list rule is invalid: " + err.Error())
 		}
 	}
 	return nil
 }
+
+func validateScraperRules(rules string) *locale.LocalizedError {
+	if rules == "" {
+		return nil
+	} else if err := scraper.ValidateRules(rules); err != nil {
+		return locale.NewLocalizedError("Invalid scraper rules: " + err.Error())
+	}
+	return nil
+}
+
+func validateURLRewriteRules(rules string) *locale.LocalizedError {
+	if err := rewrite.ValidateURLRewriteRules(rules); err != nil {
+		return locale.NewLocalizedError(
+			"Invalid URL rewrite rules: " + err.Error())
+	}
+	return nil
+}
@@ -41,3 +41,36 @@ func TestValidateFeedModificationProxyURL(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFeedModificationRefreshInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval int
+		wantErr  bool
+	}{
+		{
+			name:     "default refresh interval",
+			interval: 0,
+			wantErr:  false,
+		},
+		{
+			name:     "custom refresh interval",
+			interval: 5,
+			wantErr:  false,
+		},
+		{
+			name:     "negative refresh interval",
+			interval: -1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &model.FeedModificationRequest{RefreshInterval: &tc.interval}
+			if err := ValidateFeedModification(t.Context(), nil, 0, 0, request); (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
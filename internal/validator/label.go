@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"context"
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateLabel validates label creation, when labelID is zero, or
+// modification.
+func ValidateLabel(ctx context.Context, store *storage.Storage,
+	userID, labelID int64, r *model.LabelRequest,
+) *locale.LocalizedError {
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return locale.NewLocalizedError("error.title_required")
+	}
+
+	if store.AnotherLabelExists(ctx, userID, labelID, r.Title) {
+		return locale.NewLocalizedError("This label already exists.")
+	}
+
+	// Google Reader clients see categories and labels as the same kind of
+	// streams, so their titles must not collide.
+	if store.CategoryTitleExists(ctx, userID, r.Title) {
+		return locale.NewLocalizedError(
+			"A category with the same title already exists.")
+	}
+	return nil
+}
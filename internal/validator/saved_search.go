@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateSavedSearch validates saved search creation, when id is zero, or
+// modification.
+func ValidateSavedSearch(ctx context.Context, store *storage.Storage,
+	userID, id int64, r *model.SavedSearchRequest,
+) *locale.LocalizedError {
+	r.Title = strings.TrimSpace(r.Title)
+	if r.Title == "" {
+		return locale.NewLocalizedError("error.title_required")
+	}
+
+	r.Query = strings.TrimSpace(r.Query)
+	q, err := model.ParseSearchQuery(r.Query, time.UTC)
+	if err != nil {
+		return locale.NewLocalizedError("error.saved_search_invalid_query", err)
+	} else if q.Empty() {
+		return locale.NewLocalizedError("error.saved_search_query_required")
+	}
+
+	if store.AnotherSavedSearchExists(ctx, userID, id, r.Title) {
+		return locale.NewLocalizedError("error.saved_search_already_exists")
+	}
+	return nil
+}
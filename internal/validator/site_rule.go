@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/scraper"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// ValidateSiteRule validates site rule creation, when id is zero, or
+// modification. userID is the owner of the site rule, zero for global rules.
+func ValidateSiteRule(ctx context.Context, store *storage.Storage,
+	userID, id int64, r *model.SiteRuleRequest,
+) *locale.LocalizedError {
+	if lerr := ValidateSiteRuleRequest(r); lerr != nil {
+		return lerr
+	}
+
+	if store.AnotherSiteRuleExists(ctx, userID, id, r.Hostname) {
+		return locale.NewLocalizedError("error.site_rule_already_exists")
+	}
+	return nil
+}
+
+// ValidateSiteRuleRequest normalizes and validates a site rule without
+// looking for duplicates.
+func ValidateSiteRuleRequest(r *model.SiteRuleRequest) *locale.LocalizedError {
+	r.Hostname = strings.TrimSuffix(
+		strings.ToLower(strings.TrimSpace(r.Hostname)), ".")
+	if r.Hostname == "" {
+		return locale.NewLocalizedError("error.site_rule_hostname_required")
+	} else if !validHostname(r.Hostname) {
+		return locale.NewLocalizedError("error.site_rule_invalid_hostname",
+			r.Hostname)
+	}
+
+	r.ScraperRules = strings.TrimSpace(r.ScraperRules)
+	r.RewriteRules = strings.TrimSpace(r.RewriteRules)
+	r.Referer = strings.TrimSpace(r.Referer)
+	r.UserAgent = strings.TrimSpace(r.UserAgent)
+	if r.ScraperRules == "" && r.RewriteRules == "" && r.Referer == "" &&
+		r.UserAgent == "" {
+		return locale.NewLocalizedError("error.site_rule_no_rules")
+	}
+
+	if r.ScraperRules != "" {
+		if err := scraper.ValidateRules(r.ScraperRules); err != nil {
+			return locale.NewLocalizedError(
+				"error.site_rule_invalid_scraper_rules", err)
+		}
+	}
+
+	if err := rewrite.ValidateContentRewriteRules(r.RewriteRules); err != nil {
+		return locale.NewLocalizedError(
+			"error.site_rule_invalid_rewrite_rules", err)
+	}
+
+	if r.Referer != "" && !urllib.IsAbsoluteURL(r.Referer) {
+		return locale.NewLocalizedError("error.site_rule_invalid_referer",
+			r.Referer)
+	}
+
+	if strings.ContainsAny(r.UserAgent, "\r\n") {
+		return locale.NewLocalizedError("error.site_rule_invalid_user_agent")
+	}
+	return nil
+}
+
+func validHostname(hostname string) bool {
+	u, err := url.Parse("http://" + hostname)
+	return err == nil && u.Host == hostname && u.Hostname() == hostname &&
+		u.Path == "" && u.User == nil
+}
@@ -146,6 +146,13 @@ func ValidateUserModification(ctx context.Context, store *storage.Storage,
 		}
 	}
 
+	if s := model.OptionalValue(r.TagEntryRules); s != "" {
+		if _, err := filter.NewTagger(s); err != nil {
+			return locale.NewLocalizedError(
+				"The tag rule is invalid: " + err.Error())
+		}
+	}
+
 	if r.ExternalFontHosts != nil {
 		if !IsValidDomainList(*r.ExternalFontHosts) {
 			return locale.NewLocalizedError("error.settings_invalid_domain_list")
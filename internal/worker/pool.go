@@ -256,6 +256,7 @@ func (self *Pool) refreshFeed(job *queueItem) (*model.FeedRefreshed, error) {
 		job.err = err
 		log.Error("worker: error refreshing feed", slog.Any("error", err))
 	}
+	publishRefreshed(job, refreshed)
 
 	if config.HasMetricsCollector() {
 		status := metric.StatusSuccess
@@ -269,6 +270,35 @@ func (self *Pool) refreshFeed(job *queueItem) (*model.FeedRefreshed, error) {
 	return refreshed, err
 }
 
+// publishRefreshed publishes events of entries created by the refresh of the
+// feed and of changed counters of the feed.
+func publishRefreshed(job *queueItem, refreshed *model.FeedRefreshed) {
+	if refreshed == nil {
+		return
+	}
+
+	events := job.store.Events()
+	if n := refreshed.CreatedLen(); n != 0 {
+		entryIDs := make([]int64, n)
+		for i, e := range refreshed.Created {
+			entryIDs[i] = e.ID
+		}
+		events.Publish(job.UserID, &model.Event{
+			Type:     model.EventEntriesCreated,
+			FeedID:   job.FeedID,
+			EntryIDs: entryIDs,
+		})
+	}
+
+	if refreshed.CreatedLen() != 0 || refreshed.UpdatedLen() != 0 ||
+		refreshed.Deleted != 0 {
+		events.Publish(job.UserID, &model.Event{
+			Type:   model.EventFeedCounters,
+			FeedID: job.FeedID,
+		})
+	}
+}
+
 func entriesLogGroup(items []queueItem, dd *storage.DedupEntries) slog.Attr {
 	var newEntries, deleted, updated, dedups int
 	for i := range items {